@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Olden/sudoku-solver/batch"
+)
+
+func batchMode(args []string) error {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print results as JSON lines")
+	steps := fs.Bool("steps", false, "include solving steps in JSON results")
+	workers := fs.Int("workers", 0, "number of puzzles solved at once (default GOMAXPROCS)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s batch [flags] [file]\n\nSolves puzzles, one per line, read from the file or stdin.\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	in, err := openInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	emit := batch.TextWriter(out)
+	if *asJSON {
+		emit = batch.JSONWriter(out)
+	}
+
+	return batch.Solve(context.Background(), in, batch.Options{Workers: *workers, Steps: *steps}, emit)
+}
+
+// openInput opens the named file, or stdin if name is empty or "-"
+func openInput(name string) (io.ReadCloser, error) {
+	if name == "" || name == "-" {
+		return os.Stdin, nil
+	}
+
+	return os.Open(name)
+}
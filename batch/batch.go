@@ -0,0 +1,178 @@
+// Package batch solves collections of puzzles concurrently.
+package batch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+// Options configure a batch run
+type Options struct {
+	// Workers is the number of puzzles solved at once, GOMAXPROCS by default
+	Workers int
+	// Steps keeps solving steps in the results
+	Steps bool
+	// Query matches the puzzles against the query instead of solving them
+	// with all strategies
+	Query *Query
+}
+
+// Result is the outcome of solving a single puzzle of the batch
+type Result struct {
+	// Line is the line number of the puzzle in the input
+	Line   int            `json:"line"`
+	Puzzle string         `json:"puzzle"`
+	Result *solver.Result `json:"result,omitempty"`
+	// Error is the reason the puzzle couldn't be solved
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration_ns"`
+	// Match reports whether the puzzle matches the query of the options
+	Match bool `json:"match,omitempty"`
+	// Before is the candidate grid just before the required strategy
+	// applies, if the query asks for it
+	Before string `json:"before,omitempty"`
+}
+
+// Solve reads puzzles from r, one per line, and solves them on a pool of
+// workers. Empty lines and lines starting with '#' are skipped. Results are
+// passed to emit in input order. A puzzle that fails doesn't stop the batch,
+// its Result has an Error instead. Solve stops on the first read error,
+// emit error or when ctx is done. An invalid query is an error
+func Solve(ctx context.Context, r io.Reader, o Options, emit func(Result) error) error {
+	if o.Query != nil {
+		if err := o.Query.Validate(); err != nil {
+			return err
+		}
+	}
+	workers := o.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type job struct {
+		line   int
+		puzzle string
+		out    chan Result
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan job)
+	// pending holds results in input order, its capacity bounds
+	// the number of puzzles in flight
+	pending := make(chan chan Result, 4*workers)
+	var readErr error
+
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+
+		sc := bufio.NewScanner(r)
+		for n := 1; sc.Scan(); n++ {
+			puzzle := strings.TrimSpace(sc.Text())
+			if puzzle == "" || strings.HasPrefix(puzzle, "#") {
+				continue
+			}
+
+			j := job{n, puzzle, make(chan Result, 1)}
+			select {
+			case pending <- j.out:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr = sc.Err()
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for j := range jobs {
+				j.out <- solve(j.line, j.puzzle, o)
+			}
+		}()
+	}
+
+	for out := range pending {
+		select {
+		case r := <-out:
+			if err := emit(r); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return readErr
+}
+
+func solve(line int, puzzle string, o Options) (r Result) {
+	r = Result{Line: line, Puzzle: puzzle}
+	start := time.Now()
+	defer func() {
+		r.Duration = time.Since(start)
+		if err := recover(); err != nil {
+			r.Result = nil
+			r.Error = fmt.Sprint(err)
+		}
+	}()
+
+	b, err := solver.Parse(nil, puzzle)
+	if err != nil {
+		r.Error = err.Error()
+		return r
+	}
+
+	var res solver.Result
+	if o.Query != nil {
+		res, r.Match, r.Before = o.Query.match(b)
+	} else {
+		res = b.Solve()
+	}
+	if !o.Steps {
+		res.Steps = []solver.Step{}
+	}
+	r.Result = &res
+
+	return r
+}
+
+// JSONWriter returns an emit function writing results as JSON lines
+func JSONWriter(w io.Writer) func(Result) error {
+	e := json.NewEncoder(w)
+	return func(r Result) error {
+		return e.Encode(r)
+	}
+}
+
+// TextWriter returns an emit function writing results as tab separated lines:
+// line number, final grid, hardest strategy, rating and level,
+// or line number and error
+func TextWriter(w io.Writer) func(Result) error {
+	return func(r Result) error {
+		if r.Error != "" {
+			_, err := fmt.Fprintf(w, "%d\terror: %s\n", r.Line, r.Error)
+			return err
+		}
+
+		_, err := fmt.Fprintf(w, "%d\t%s\t%s\t%.1f\t%s\n", r.Line, r.Result.Grid, r.Result.HardestStrategy, r.Result.Rating.Max, r.Result.Rating.Level())
+		return err
+	}
+}
@@ -0,0 +1,76 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testPuzzles = []string{
+	"000000001000000020000003000000040500006000300007810000010020004030000070950000000",
+	"000000000000000012003045000000000400000600000060100070000260080405000009700000000",
+	"000000001000000023004005000000002000010000400360070000000610000005000800007030000",
+	"000000001000000023004005000000006000070000000120030000000210070006000400500080000",
+}
+
+func TestSolve(t *testing.T) {
+	input := "# test puzzles\n" + strings.Join(testPuzzles, "\n") + "\n\ninvalid\n" + testPuzzles[0] + "\n"
+
+	results := []Result{}
+	err := Solve(context.Background(), strings.NewReader(input), Options{Workers: 3}, func(r Result) error {
+		results = append(results, r)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []int{2, 3, 4, 5, 7, 8}
+	if len(results) != len(lines) {
+		t.Fatalf("%d results, expected %d", len(results), len(lines))
+	}
+	for i, r := range results {
+		if r.Line != lines[i] {
+			t.Errorf("result %d is from line %d, expected %d", i, r.Line, lines[i])
+		}
+		if r.Line == 7 {
+			if r.Error == "" || r.Result != nil {
+				t.Errorf("invalid puzzle must have an error: %+v", r)
+			}
+			continue
+		}
+		if r.Error != "" || !r.Result.Solved || len(r.Result.Steps) != 0 {
+			t.Errorf("puzzle on line %d is not solved: %+v", r.Line, r)
+		}
+	}
+}
+
+func TestSolveEmitError(t *testing.T) {
+	input := strings.Repeat(testPuzzles[0]+"\n", 100)
+	stop := errors.New("stop")
+
+	n := 0
+	err := Solve(context.Background(), strings.NewReader(input), Options{Workers: 2}, func(r Result) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 {
+		t.Errorf("emit error must stop the batch, error: %v, emitted: %d", err, n)
+	}
+}
+
+func TestTextWriter(t *testing.T) {
+	w := &bytes.Buffer{}
+	err := Solve(context.Background(), strings.NewReader(testPuzzles[2]+"\nx\n"), Options{}, TextWriter(w))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ex := "1\t276389541581746923934125678458962317712853469369471285893614752145297836627538194\thidden pairs\t3.4\tMedium\n" +
+		"2\terror: line 1, column 1: unexpected character 'x'\n"
+	if w.String() != ex {
+		t.Errorf("text output: %q, expected: %q", w.String(), ex)
+	}
+}
@@ -0,0 +1,96 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+// Query selects puzzles whose solving requires a strategy, e.g. puzzles
+// which need hidden pairs with nothing harder than naked triples
+type Query struct {
+	// Requires is the strategy a puzzle can't be solved without
+	Requires string
+	// Hardest is the hardest strategy allowed, all strategies by default
+	Hardest string
+	// Before keeps the candidate grid of the board just before the first
+	// step of the required strategy
+	Before bool
+}
+
+// Validate returns an error if the query names an unknown strategy or
+// requires a strategy harder than the hardest one allowed
+func (q Query) Validate() error {
+	_, err := q.selection()
+	return err
+}
+
+// selection returns the strategies allowed by the query
+func (q Query) selection() (solver.Selection, error) {
+	r := solver.NewRegistry()
+	required, ok := r.Lookup(q.Requires)
+	if !ok {
+		return solver.Selection{}, fmt.Errorf("unknown strategy %q", q.Requires)
+	}
+
+	max := math.Inf(1)
+	if q.Hardest != "" {
+		hardest, ok := r.Lookup(q.Hardest)
+		if !ok {
+			return solver.Selection{}, fmt.Errorf("unknown strategy %q", q.Hardest)
+		}
+		if required.Difficulty() > hardest.Difficulty() {
+			return solver.Selection{}, fmt.Errorf("strategy %q is harder than %q", q.Requires, q.Hardest)
+		}
+		max = hardest.Difficulty()
+	}
+
+	sel := solver.Selection{Include: []string{}}
+	for _, s := range r.Strategies() {
+		if s.Difficulty() <= max {
+			sel.Include = append(sel.Include, s.Name())
+		}
+	}
+
+	return sel, nil
+}
+
+// match solves the board with the strategies allowed by the query. The
+// puzzle matches if it's solved using the required strategy but can't be
+// solved without it. before is the candidate grid just before the first
+// step of the required strategy if the query asks for it
+func (q Query) match(b *solver.Board) (res solver.Result, match bool, before string) {
+	sel, err := q.selection()
+	if err != nil {
+		panic(err)
+	}
+	without := b.Clone()
+
+	res = b.SolveContext(context.Background(), solver.SolveOptions{Strategies: sel})
+	first := -1
+	for i, s := range res.Steps {
+		if s.Strategy == q.Requires {
+			first = i
+			break
+		}
+	}
+	if !res.Solved || first < 0 {
+		return res, false, ""
+	}
+
+	sel.Exclude = []string{q.Requires}
+	if without.SolveContext(context.Background(), solver.SolveOptions{Strategies: sel}).Solved {
+		return res, false, ""
+	}
+
+	if q.Before {
+		for i := len(res.Steps); i > first; i-- {
+			b.Undo()
+		}
+		before = b.CandidateString()
+	}
+
+	return res, true, before
+}
@@ -0,0 +1,67 @@
+package batch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+func TestSolveQuery(t *testing.T) {
+	input := strings.Join(testPuzzles, "\n")
+	q := &Query{Requires: "hidden pairs", Hardest: "hidden pairs", Before: true}
+
+	matches := []Result{}
+	err := Solve(context.Background(), strings.NewReader(input), Options{Query: q}, func(r Result) error {
+		if r.Match {
+			matches = append(matches, r)
+		} else if r.Before != "" {
+			t.Errorf("puzzle on line %d doesn't match, but has a board before the strategy", r.Line)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Line != 3 || !matches[0].Result.Solved {
+		t.Fatalf("only the puzzle on line 3 needs hidden pairs with nothing harder, matches: %+v", matches)
+	}
+
+	b := solver.NewBoard(nil, matches[0].Before)
+	if steps, err := solver.FindAll(b, "hidden pairs"); err != nil || len(steps) == 0 {
+		t.Errorf("hidden pairs must apply to the board before them:\n%s\nerror: %v", matches[0].Before, err)
+	}
+	if steps, _ := solver.FindAll(b, "naked pairs"); len(steps) != 0 {
+		t.Errorf("naked pairs must not apply to the board before hidden pairs: %v", steps)
+	}
+
+	// without the limit naked triples can replace hidden pairs
+	err = Solve(context.Background(), strings.NewReader(input), Options{Query: &Query{Requires: "hidden pairs"}}, func(r Result) error {
+		if r.Match != (r.Line == 4) {
+			t.Errorf("puzzle on line %d, match: %v, hardest: %s", r.Line, r.Match, r.Result.HardestStrategy)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	errors := map[Query]string{
+		{Requires: "x-wing"}:                                 `unknown strategy "x-wing"`,
+		{Requires: "naked pairs", Hardest: "swordfish"}:      `unknown strategy "swordfish"`,
+		{Requires: "hidden quads", Hardest: "naked triples"}: `strategy "hidden quads" is harder than "naked triples"`,
+	}
+	for q, ex := range errors {
+		err := Solve(context.Background(), strings.NewReader(testPuzzles[0]), Options{Query: &q}, func(Result) error { return nil })
+		if err == nil || err.Error() != ex {
+			t.Errorf("%+v: error is: %v, expected: %s", q, err, ex)
+		}
+	}
+
+	if err := (Query{Requires: "naked pairs", Hardest: "hidden quads"}).Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,186 @@
+package batch
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Stats aggregates results of a batch. Per strategy counts need results
+// with steps, see Options.Steps
+type Stats struct {
+	puzzles, unsolved, errors int
+	hardest                   map[string]int
+	strategies                map[string]*StrategyStats
+	ratings                   map[float64]int
+	durations                 []time.Duration
+}
+
+// StrategyStats are statistics of a single strategy
+type StrategyStats struct {
+	Name string `json:"name"`
+	// Fired is the number of steps made by the strategy
+	Fired int `json:"fired"`
+	// Puzzles is the number of puzzles the strategy was used for
+	Puzzles    int `json:"puzzles"`
+	Placements int `json:"placements"`
+	// AvgPlacements is the average number of placements per step
+	AvgPlacements float64 `json:"avg_placements"`
+}
+
+// RatingBucket is a bar of the rating histogram
+type RatingBucket struct {
+	Rating float64 `json:"rating"`
+	Count  int     `json:"count"`
+}
+
+// Timing are percentiles of solving time
+type Timing struct {
+	P50 time.Duration `json:"p50_ns"`
+	P90 time.Duration `json:"p90_ns"`
+	P99 time.Duration `json:"p99_ns"`
+	Max time.Duration `json:"max_ns"`
+}
+
+// Report is the summary of a batch
+type Report struct {
+	Puzzles  int `json:"puzzles"`
+	Solved   int `json:"solved"`
+	Unsolved int `json:"unsolved"`
+	Errors   int `json:"errors"`
+	// Hardest counts puzzles per the hardest strategy used
+	Hardest    map[string]int  `json:"hardest"`
+	Strategies []StrategyStats `json:"strategies"`
+	Ratings    []RatingBucket  `json:"ratings"`
+	Timing     Timing          `json:"timing"`
+}
+
+// NewStats creates empty statistics
+func NewStats() *Stats {
+	return &Stats{
+		hardest:    map[string]int{},
+		strategies: map[string]*StrategyStats{},
+		ratings:    map[float64]int{},
+	}
+}
+
+// Add adds the result to the statistics
+func (s *Stats) Add(r Result) {
+	s.puzzles++
+	s.durations = append(s.durations, r.Duration)
+	if r.Error != "" {
+		s.errors++
+		return
+	}
+	if !r.Result.Solved {
+		s.unsolved++
+	}
+
+	s.hardest[r.Result.HardestStrategy]++
+	s.ratings[r.Result.Rating.Max]++
+
+	used := map[string]bool{}
+	for _, step := range r.Result.Steps {
+		st, ok := s.strategies[step.Strategy]
+		if !ok {
+			st = &StrategyStats{Name: step.Strategy}
+			s.strategies[step.Strategy] = st
+		}
+		st.Fired++
+		st.Placements += len(step.Placements)
+		if !used[step.Strategy] {
+			used[step.Strategy] = true
+			st.Puzzles++
+		}
+	}
+}
+
+// Report summarizes the statistics
+func (s *Stats) Report() Report {
+	r := Report{
+		Puzzles:  s.puzzles,
+		Solved:   s.puzzles - s.unsolved - s.errors,
+		Unsolved: s.unsolved,
+		Errors:   s.errors,
+		Hardest:  map[string]int{},
+	}
+	for k, v := range s.hardest {
+		r.Hardest[k] = v
+	}
+
+	r.Strategies = []StrategyStats{}
+	for _, st := range s.strategies {
+		v := *st
+		v.AvgPlacements = math.Round(float64(v.Placements)/float64(v.Fired)*100) / 100
+		r.Strategies = append(r.Strategies, v)
+	}
+	sort.Slice(r.Strategies, func(i, j int) bool {
+		if r.Strategies[i].Fired != r.Strategies[j].Fired {
+			return r.Strategies[i].Fired > r.Strategies[j].Fired
+		}
+		return r.Strategies[i].Name < r.Strategies[j].Name
+	})
+
+	r.Ratings = []RatingBucket{}
+	for k, v := range s.ratings {
+		r.Ratings = append(r.Ratings, RatingBucket{k, v})
+	}
+	sort.Slice(r.Ratings, func(i, j int) bool { return r.Ratings[i].Rating < r.Ratings[j].Rating })
+
+	d := append([]time.Duration{}, s.durations...)
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	r.Timing = Timing{percentile(d, 50), percentile(d, 90), percentile(d, 99), percentile(d, 100)}
+
+	return r
+}
+
+// percentile returns the nearest rank percentile p of sorted durations
+func percentile(d []time.Duration, p float64) time.Duration {
+	if len(d) == 0 {
+		return 0
+	}
+
+	i := int(math.Ceil(p/100*float64(len(d)))) - 1
+	if i < 0 {
+		i = 0
+	}
+
+	return d[i]
+}
+
+func (r Report) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "Puzzles: %d, solved: %d, unsolved: %d, errors: %d\n", r.Puzzles, r.Solved, r.Unsolved, r.Errors)
+
+	fmt.Fprintln(b, "\nHardest strategy:")
+	hardest := []string{}
+	for k := range r.Hardest {
+		hardest = append(hardest, k)
+	}
+	sort.Slice(hardest, func(i, j int) bool {
+		if r.Hardest[hardest[i]] != r.Hardest[hardest[j]] {
+			return r.Hardest[hardest[i]] > r.Hardest[hardest[j]]
+		}
+		return hardest[i] < hardest[j]
+	})
+	for _, k := range hardest {
+		fmt.Fprintf(b, "  %-20s %8d\n", k, r.Hardest[k])
+	}
+
+	fmt.Fprintln(b, "\nStrategies:")
+	fmt.Fprintf(b, "  %-20s %8s %8s %12s\n", "name", "fired", "puzzles", "avg placed")
+	for _, s := range r.Strategies {
+		fmt.Fprintf(b, "  %-20s %8d %8d %12.2f\n", s.Name, s.Fired, s.Puzzles, s.AvgPlacements)
+	}
+
+	fmt.Fprintln(b, "\nRatings:")
+	for _, bucket := range r.Ratings {
+		fmt.Fprintf(b, "  %4.1f %8d\n", bucket.Rating, bucket.Count)
+	}
+
+	fmt.Fprintf(b, "\nTiming: p50 %s, p90 %s, p99 %s, max %s\n", r.Timing.P50, r.Timing.P90, r.Timing.P99, r.Timing.Max)
+
+	return b.String()
+}
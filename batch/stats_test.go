@@ -0,0 +1,57 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	input := strings.Join(testPuzzles, "\n") + "\ninvalid\n"
+
+	s := NewStats()
+	err := Solve(context.Background(), strings.NewReader(input), Options{Steps: true}, func(r Result) error {
+		s.Add(r)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := s.Report()
+	if r.Puzzles != 5 || r.Solved != 4 || r.Errors != 1 || r.Unsolved != 0 {
+		t.Errorf("unexpected counts: %+v", r)
+	}
+	if r.Hardest["hidden pairs"] != 1 || r.Hardest["hidden quads"] != 1 {
+		t.Errorf("unexpected hardest strategies: %v", r.Hardest)
+	}
+	if r.Strategies[0].Name != "hidden singles" || r.Strategies[0].Puzzles != 4 {
+		t.Errorf("hidden singles must fire most often: %+v", r.Strategies)
+	}
+	if len(r.Ratings) == 0 || r.Timing.Max == 0 {
+		t.Errorf("no rating histogram or timing: %+v", r)
+	}
+
+	if _, err := json.Marshal(r); err != nil {
+		t.Error(err)
+	}
+	if !strings.Contains(r.String(), "Puzzles: 5, solved: 4, unsolved: 0, errors: 1") {
+		t.Errorf("unexpected text report:\n%s", r)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	d := []time.Duration{}
+	for i := 1; i <= 100; i++ {
+		d = append(d, time.Duration(i))
+	}
+
+	ex := map[float64]time.Duration{50: 50, 90: 90, 99: 99, 100: 100, 0: 1}
+	for p, v := range ex {
+		if r := percentile(d, p); r != v {
+			t.Errorf("percentile %v is: %v, expected: %v", p, r, v)
+		}
+	}
+}
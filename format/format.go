@@ -0,0 +1,142 @@
+// Package format reads and writes Sudoku puzzle files in common formats:
+// SadMan Sudoku (.sdk, .sdm), Simple Sudoku (.ss) and OpenSudoku XML.
+package format
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// Format is a puzzle file format
+type Format string
+
+// Supported formats
+const (
+	Unknown    Format = ""
+	SDK        Format = "sdk"
+	SDM        Format = "sdm"
+	SS         Format = "ss"
+	OpenSudoku Format = "opensudoku"
+)
+
+// Puzzle is a Sudoku puzzle with its metadata
+type Puzzle struct {
+	// Givens are 81 digits row by row, 0 is an empty cell
+	Givens      string
+	Name        string
+	Author      string
+	Description string
+	Comment     string
+	Source      string
+	Level       string
+}
+
+// ErrUnknownFormat is returned when the format of the data can't be detected
+var ErrUnknownFormat = errors.New("unknown puzzle format")
+
+// Sniff detects the format of the data
+func Sniff(data []byte) Format {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("<")) && bytes.Contains(data, []byte("<opensudoku")) {
+		return OpenSudoku
+	}
+
+	lines := contentLines(string(data))
+	if len(lines) == 0 {
+		return Unknown
+	}
+
+	if strings.HasPrefix(lines[0], "#") || strings.HasPrefix(lines[0], "[") {
+		return SDK
+	}
+	if strings.Contains(string(data), "|") {
+		return SS
+	}
+	if len(lines[0]) == 81 {
+		return SDM
+	}
+	if len(lines) >= 9 && len(lines[0]) == 9 {
+		return SDK
+	}
+
+	return Unknown
+}
+
+// Read reads all puzzles from r detecting its format
+func Read(r io.Reader) ([]Puzzle, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch Sniff(data) {
+	case SDK:
+		p, err := ReadSDK(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return []Puzzle{p}, nil
+	case SDM:
+		return ReadSDM(bytes.NewReader(data))
+	case SS:
+		p, err := ReadSS(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return []Puzzle{p}, nil
+	case OpenSudoku:
+		return ReadOpenSudoku(bytes.NewReader(data))
+	}
+
+	return nil, ErrUnknownFormat
+}
+
+// contentLines returns trimmed non-empty lines of s
+func contentLines(s string) []string {
+	r := []string{}
+	sc := bufio.NewScanner(strings.NewReader(s))
+	for sc.Scan() {
+		if l := strings.TrimSpace(sc.Text()); l != "" {
+			r = append(r, l)
+		}
+	}
+
+	return r
+}
+
+// givens converts a row of a puzzle to digits, blank is the set of
+// characters used for empty cells
+func givens(row, blank string) (string, error) {
+	r := []byte{}
+	for _, ch := range row {
+		switch {
+		case ch >= '1' && ch <= '9':
+			r = append(r, byte(ch))
+		case ch == '0' || strings.ContainsRune(blank, ch):
+			r = append(r, '0')
+		default:
+			return "", fmt.Errorf("invalid character %q", ch)
+		}
+	}
+
+	return string(r), nil
+}
+
+// cells returns the givens with '.' for empty cells
+func (p Puzzle) cells() (string, error) {
+	if len(p.Givens) != 81 {
+		return "", fmt.Errorf("puzzle must have 81 cells, got %d", len(p.Givens))
+	}
+
+	g, err := givens(p.Givens, ".")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Replace(g, "0", ".", -1), nil
+}
@@ -0,0 +1,62 @@
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+const testGivens = "000000001000000023004005000000002000010000400360070000000610000005000800007030000"
+
+func TestSniff(t *testing.T) {
+	ex := map[string]Format{
+		"#Aauthor\n.........\n":                 SDK,
+		"[Puzzle]\n.........\n":                 SDK,
+		strings.Repeat(".........\n", 9):        SDK,
+		testGivens + "\n" + testGivens + "\n":   SDM,
+		"...|...|...\n":                         SS,
+		"<?xml version=\"1.0\"?>\n<opensudoku>": OpenSudoku,
+		"hello":                                 Unknown,
+		"":                                      Unknown,
+	}
+
+	for data, f := range ex {
+		if r := Sniff([]byte(data)); r != f {
+			t.Errorf("format of %q is: %q, expected: %q", data, r, f)
+		}
+	}
+}
+
+func TestRead(t *testing.T) {
+	formats := map[string]string{
+		"sdk": ".........\n" +
+			"........1\n" +
+			strings.Repeat(".........\n", 7),
+		"sdm":        strings.Repeat("0", 17) + "1" + strings.Repeat("0", 63),
+		"ss":         "...|...|...\n...|...|..1\n" + strings.Repeat("...|...|...\n", 7),
+		"opensudoku": `<opensudoku><game data="` + strings.Repeat("0", 17) + "1" + strings.Repeat("0", 63) + `"/></opensudoku>`,
+	}
+	ex := strings.Repeat("0", 17) + "1" + strings.Repeat("0", 63)
+
+	for f, data := range formats {
+		ps, err := Read(strings.NewReader(data))
+		if err != nil || len(ps) != 1 || ps[0].Givens != ex {
+			t.Errorf("%s: puzzles: %+v, error: %v", f, ps, err)
+		}
+	}
+
+	invalid := map[string]string{
+		"sdk":        "#Aname\n.........\n",
+		"sdm":        ex + "\n" + strings.Repeat("x", 81) + "\n",
+		"ss":         "...|...|...\n",
+		"opensudoku": `<opensudoku><game data="` + ex + `"/><game data="123"/></opensudoku>`,
+	}
+	for f, data := range invalid {
+		if ps, err := Read(strings.NewReader(data)); err == nil || ps != nil {
+			t.Errorf("%s: invalid puzzle must be an error without puzzles, puzzles: %+v, error: %v", f, ps, err)
+		}
+	}
+
+	if _, err := Read(strings.NewReader("hello")); err != ErrUnknownFormat {
+		t.Errorf("unknown format error is: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package format
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type openSudoku struct {
+	XMLName     xml.Name         `xml:"opensudoku"`
+	Version     string           `xml:"version,attr,omitempty"`
+	Name        string           `xml:"name,omitempty"`
+	Author      string           `xml:"author,omitempty"`
+	Description string           `xml:"description,omitempty"`
+	Comment     string           `xml:"comment,omitempty"`
+	Source      string           `xml:"source,omitempty"`
+	Level       string           `xml:"level,omitempty"`
+	Games       []openSudokuGame `xml:"game"`
+}
+
+type openSudokuGame struct {
+	Data string `xml:"data,attr"`
+}
+
+// ReadOpenSudoku reads an OpenSudoku XML collection. Collection metadata
+// is copied to every puzzle
+func ReadOpenSudoku(r io.Reader) ([]Puzzle, error) {
+	doc := openSudoku{}
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("opensudoku: %s", err)
+	}
+
+	ps := []Puzzle{}
+	for i, g := range doc.Games {
+		if len(g.Data) != 81 {
+			return nil, fmt.Errorf("opensudoku: game %d: puzzle must have 81 cells, got %d", i+1, len(g.Data))
+		}
+		givens, err := givens(g.Data, ".")
+		if err != nil {
+			return nil, fmt.Errorf("opensudoku: game %d: %s", i+1, err)
+		}
+		ps = append(ps, Puzzle{
+			Givens:      givens,
+			Name:        doc.Name,
+			Author:      doc.Author,
+			Description: doc.Description,
+			Comment:     doc.Comment,
+			Source:      doc.Source,
+			Level:       doc.Level,
+		})
+	}
+
+	return ps, nil
+}
+
+// WriteOpenSudoku writes the puzzles as an OpenSudoku XML collection.
+// Metadata of the first puzzle is used for the collection
+func WriteOpenSudoku(w io.Writer, ps []Puzzle) error {
+	doc := openSudoku{}
+	if len(ps) > 0 {
+		doc.Name = ps[0].Name
+		doc.Author = ps[0].Author
+		doc.Description = ps[0].Description
+		doc.Comment = ps[0].Comment
+		doc.Source = ps[0].Source
+		doc.Level = ps[0].Level
+	}
+	for i, p := range ps {
+		cells, err := p.cells()
+		if err != nil {
+			return fmt.Errorf("opensudoku: puzzle %d: %s", i+1, err)
+		}
+		g, _ := givens(cells, ".")
+		doc.Games = append(doc.Games, openSudokuGame{g})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	if err := e.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
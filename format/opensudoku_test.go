@@ -0,0 +1,46 @@
+package format
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadOpenSudoku(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<opensudoku version="2">
+  <name>Examples</name>
+  <author>John Doe</author>
+  <game created="1300000000000" state="1" time="0" data="` + testGivens + `" note="" />
+  <game data="` + testGivens + `" />
+</opensudoku>
+`
+	ps, err := ReadOpenSudoku(strings.NewReader(data))
+	p := Puzzle{Givens: testGivens, Name: "Examples", Author: "John Doe"}
+	ex := []Puzzle{p, p}
+	if err != nil || !reflect.DeepEqual(ps, ex) {
+		t.Errorf("puzzles: %+v, error: %v, expected: %+v", ps, err, ex)
+	}
+
+	ps, err = ReadOpenSudoku(strings.NewReader(`<opensudoku><game data="` + testGivens + `"/><game data="123"/></opensudoku>`))
+	if err == nil || err.Error() != `opensudoku: game 2: puzzle must have 81 cells, got 3` || ps != nil {
+		t.Errorf("short puzzle error is: %v, puzzles: %+v", err, ps)
+	}
+}
+
+func TestWriteOpenSudoku(t *testing.T) {
+	ps := []Puzzle{
+		{Givens: testGivens, Name: "Examples", Level: "easy"},
+		{Givens: testGivens, Name: "Examples", Level: "easy"},
+	}
+	b := &bytes.Buffer{}
+	if err := WriteOpenSudoku(b, ps); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ReadOpenSudoku(b)
+	if err != nil || !reflect.DeepEqual(r, ps) {
+		t.Errorf("puzzles: %+v, error: %v, expected: %+v", r, err, ps)
+	}
+}
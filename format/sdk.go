@@ -0,0 +1,87 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// sdkFields maps SadMan Sudoku header tags to puzzle fields
+var sdkFields = []struct {
+	tag   byte
+	field func(p *Puzzle) *string
+}{
+	{'N', func(p *Puzzle) *string { return &p.Name }},
+	{'A', func(p *Puzzle) *string { return &p.Author }},
+	{'D', func(p *Puzzle) *string { return &p.Description }},
+	{'C', func(p *Puzzle) *string { return &p.Comment }},
+	{'S', func(p *Puzzle) *string { return &p.Source }},
+	{'L', func(p *Puzzle) *string { return &p.Level }},
+}
+
+// ReadSDK reads a SadMan Sudoku .sdk file: optional "#X" header lines
+// followed by nine rows of nine cells, '.' or '0' for empty cells.
+// Anything after the grid, e.g. a saved [State] section, is ignored
+func ReadSDK(r io.Reader) (Puzzle, error) {
+	p := Puzzle{}
+	sc := bufio.NewScanner(r)
+	n := 0
+	for rows := 0; rows < 9 && sc.Scan(); {
+		n++
+		l := strings.TrimSpace(sc.Text())
+		switch {
+		case l == "" || l == "[Puzzle]":
+			continue
+		case strings.HasPrefix(l, "#"):
+			if len(l) < 2 {
+				continue
+			}
+			for _, f := range sdkFields {
+				if l[1] == f.tag {
+					*f.field(&p) = strings.TrimSpace(l[2:])
+				}
+			}
+			continue
+		}
+
+		if len(l) != 9 {
+			return p, fmt.Errorf("sdk: line %d: row must have 9 cells, got %d", n, len(l))
+		}
+		g, err := givens(l, ".")
+		if err != nil {
+			return p, fmt.Errorf("sdk: line %d: %s", n, err)
+		}
+		p.Givens += g
+		rows++
+	}
+	if err := sc.Err(); err != nil {
+		return p, err
+	}
+	if len(p.Givens) != 81 {
+		return p, fmt.Errorf("sdk: puzzle must have 9 rows, got %d", len(p.Givens)/9)
+	}
+
+	return p, nil
+}
+
+// WriteSDK writes the puzzle as a SadMan Sudoku .sdk file
+func WriteSDK(w io.Writer, p Puzzle) error {
+	cells, err := p.cells()
+	if err != nil {
+		return fmt.Errorf("sdk: %s", err)
+	}
+
+	b := &strings.Builder{}
+	for _, f := range sdkFields {
+		if v := *f.field(&p); v != "" {
+			fmt.Fprintf(b, "#%c%s\n", f.tag, v)
+		}
+	}
+	for i := 0; i < 81; i += 9 {
+		fmt.Fprintln(b, cells[i:i+9])
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
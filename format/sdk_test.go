@@ -0,0 +1,48 @@
+package format
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadSDK(t *testing.T) {
+	data := `#AJohn Doe
+#DHidden pairs example
+[Puzzle]
+........1
+......023
+..4..5...
+.....2...
+.1....4..
+36..7....
+...61....
+..5...8..
+..7.3....
+[State]
+`
+	p, err := ReadSDK(strings.NewReader(data))
+	ex := Puzzle{Givens: testGivens, Author: "John Doe", Description: "Hidden pairs example"}
+	if err != nil || !reflect.DeepEqual(p, ex) {
+		t.Errorf("puzzle: %+v, error: %v, expected: %+v", p, err, ex)
+	}
+
+	_, err = ReadSDK(strings.NewReader("........1\n......a23\n"))
+	if err == nil || err.Error() != `sdk: line 2: invalid character 'a'` {
+		t.Errorf("invalid character error is: %v", err)
+	}
+}
+
+func TestWriteSDK(t *testing.T) {
+	p := Puzzle{Givens: testGivens, Name: "Test", Level: "Hard"}
+	b := &bytes.Buffer{}
+	if err := WriteSDK(b, p); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ReadSDK(b)
+	if err != nil || !reflect.DeepEqual(r, p) {
+		t.Errorf("puzzle: %+v, error: %v, expected: %+v", r, err, p)
+	}
+}
@@ -0,0 +1,50 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadSDM reads a SadMan Sudoku .sdm file: one puzzle of 81 cells per line,
+// '0' or '.' for empty cells
+func ReadSDM(r io.Reader) ([]Puzzle, error) {
+	ps := []Puzzle{}
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		l := strings.TrimSpace(sc.Text())
+		if l == "" {
+			continue
+		}
+		if len(l) != 81 {
+			return nil, fmt.Errorf("sdm: line %d: puzzle must have 81 cells, got %d", n, len(l))
+		}
+		g, err := givens(l, ".")
+		if err != nil {
+			return nil, fmt.Errorf("sdm: line %d: %s", n, err)
+		}
+		ps = append(ps, Puzzle{Givens: g})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return ps, nil
+}
+
+// WriteSDM writes the puzzles as a SadMan Sudoku .sdm file
+func WriteSDM(w io.Writer, ps []Puzzle) error {
+	for i, p := range ps {
+		cells, err := p.cells()
+		if err != nil {
+			return fmt.Errorf("sdm: puzzle %d: %s", i+1, err)
+		}
+		if _, err := fmt.Fprintln(w, strings.Replace(cells, ".", "0", -1)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
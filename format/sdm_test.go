@@ -0,0 +1,35 @@
+package format
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadSDM(t *testing.T) {
+	data := testGivens + "\n\n" + strings.Replace(testGivens, "0", ".", -1) + "\n"
+	ps, err := ReadSDM(strings.NewReader(data))
+	ex := []Puzzle{{Givens: testGivens}, {Givens: testGivens}}
+	if err != nil || !reflect.DeepEqual(ps, ex) {
+		t.Errorf("puzzles: %+v, error: %v, expected: %+v", ps, err, ex)
+	}
+
+	ps, err = ReadSDM(strings.NewReader(testGivens + "\n123\n"))
+	if err == nil || err.Error() != `sdm: line 2: puzzle must have 81 cells, got 3` || ps != nil {
+		t.Errorf("short puzzle error is: %v, puzzles: %+v", err, ps)
+	}
+}
+
+func TestWriteSDM(t *testing.T) {
+	ps := []Puzzle{{Givens: testGivens}, {Givens: testGivens}}
+	b := &bytes.Buffer{}
+	if err := WriteSDM(b, ps); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ReadSDM(b)
+	if err != nil || !reflect.DeepEqual(r, ps) {
+		t.Errorf("puzzles: %+v, error: %v, expected: %+v", r, err, ps)
+	}
+}
@@ -0,0 +1,63 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadSS reads a Simple Sudoku .ss file: nine rows of cells grouped by '|',
+// blocks separated by lines of '-'. Frame lines of '*', '-', '+' and '|'
+// are skipped. Empty cells are '.', '0' or 'X'
+func ReadSS(r io.Reader) (Puzzle, error) {
+	p := Puzzle{}
+	sc := bufio.NewScanner(r)
+	n := 0
+	for rows := 0; rows < 9 && sc.Scan(); {
+		n++
+		l := strings.TrimSpace(sc.Text())
+		if l == "" || strings.Trim(l, "*-+|") == "" {
+			continue
+		}
+
+		row := strings.Replace(l, "|", "", -1)
+		if len(row) != 9 {
+			return p, fmt.Errorf("ss: line %d: row must have 9 cells, got %d", n, len(row))
+		}
+		g, err := givens(row, ".Xx")
+		if err != nil {
+			return p, fmt.Errorf("ss: line %d: %s", n, err)
+		}
+		p.Givens += g
+		rows++
+	}
+	if err := sc.Err(); err != nil {
+		return p, err
+	}
+	if len(p.Givens) != 81 {
+		return p, fmt.Errorf("ss: puzzle must have 9 rows, got %d", len(p.Givens)/9)
+	}
+
+	return p, nil
+}
+
+// WriteSS writes the puzzle as a Simple Sudoku .ss file
+func WriteSS(w io.Writer, p Puzzle) error {
+	cells, err := p.cells()
+	if err != nil {
+		return fmt.Errorf("ss: %s", err)
+	}
+
+	b := &strings.Builder{}
+	for y := 0; y < 9; y++ {
+		if y == 3 || y == 6 {
+			fmt.Fprintln(b, "-----------")
+		}
+		r := cells[y*9 : y*9+9]
+		fmt.Fprintf(b, "%s|%s|%s\n", r[0:3], r[3:6], r[6:9])
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
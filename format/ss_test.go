@@ -0,0 +1,71 @@
+package format
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadSS(t *testing.T) {
+	data := `...|...|..1
+...|...|.23
+..4|..5|...
+-----------
+...|..2|...
+.1.|...|4..
+36.|.7.|...
+-----------
+...|61.|...
+..5|...|8..
+..7|.3.|...
+`
+	p, err := ReadSS(strings.NewReader(data))
+	if err != nil || p.Givens != testGivens {
+		t.Errorf("puzzle: %+v, error: %v, expected: %s", p, err, testGivens)
+	}
+
+	_, err = ReadSS(strings.NewReader("...|...|..1\n...|...|.2\n"))
+	if err == nil || err.Error() != `ss: line 2: row must have 9 cells, got 8` {
+		t.Errorf("short row error is: %v", err)
+	}
+}
+
+func TestReadSSBordered(t *testing.T) {
+	data := `*-----------*
+|...|...|..1|
+|...|...|.23|
+|..4|..5|...|
+|---+---+---|
+|...|..2|...|
+|.1.|...|4..|
+|36.|.7.|...|
+|---+---+---|
+|...|61.|...|
+|..5|...|8..|
+|..7|.3.|...|
+*-----------*
+`
+	p, err := ReadSS(strings.NewReader(data))
+	if err != nil || p.Givens != testGivens {
+		t.Errorf("puzzle: %+v, error: %v, expected: %s", p, err, testGivens)
+	}
+
+	ps, err := Read(strings.NewReader(data))
+	if err != nil || len(ps) != 1 || ps[0].Givens != testGivens {
+		t.Errorf("puzzles: %+v, error: %v", ps, err)
+	}
+}
+
+func TestWriteSS(t *testing.T) {
+	p := Puzzle{Givens: testGivens}
+	b := &bytes.Buffer{}
+	if err := WriteSS(b, p); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ReadSS(b)
+	if err != nil || !reflect.DeepEqual(r, p) {
+		t.Errorf("puzzle: %+v, error: %v, expected: %+v", r, err, p)
+	}
+}
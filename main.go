@@ -1,9 +1,99 @@
 package main
 
 import (
-	"github.com/davecgh/go-spew/spew"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Olden/sudoku-solver/solver"
 )
 
+// modes are the commands of the program, solve is the default one
+var modes = map[string]func(args []string) error{
+	"solve": solveMode,
+	"batch": batchMode,
+	"stats": statsMode,
+	"query": queryMode,
+	"serve": serveMode,
+}
+
 func main() {
-	spew.Dump(float64(5 / 2))
+	args := os.Args[1:]
+	mode := "solve"
+	if len(args) > 0 && modes[args[0]] != nil {
+		mode, args = args[0], args[1:]
+	}
+
+	if err := modes[mode](args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func solveMode(args []string) error {
+	fs := flag.NewFlagSet("solve", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	only := fs.String("only", "", "comma separated strategies to use, all if empty")
+	without := fs.String("without", "", "comma separated strategies not to use")
+	simplest := fs.Bool("simplest", false, "apply the simplest deduction at each step")
+	compact := fs.Bool("compact", false, "drop the steps the solution doesn't depend on")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [solve] [-json] [-only strategies] [-without strategies] [-simplest] [-compact] [puzzle]\n\nThe puzzle is read from stdin if not given.\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	puzzle := strings.Join(fs.Args(), "\n")
+	if puzzle == "" {
+		data, err := ioutil.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		puzzle = string(data)
+	}
+
+	var l *log.Logger
+	if !*asJSON {
+		l = log.New(os.Stdout, "", 0)
+	}
+
+	b, err := solver.Parse(l, puzzle)
+	if err != nil {
+		return err
+	}
+	sel := solver.Selection{Include: strategyNames(*only), Exclude: strategyNames(*without)}
+	if err := b.CheckSelection(sel); err != nil {
+		return err
+	}
+	var r solver.Result
+	if len(sel.Include) > 0 || len(sel.Exclude) > 0 || *simplest || *compact {
+		r = b.SolveContext(context.Background(), solver.SolveOptions{Strategies: sel, Simplest: *simplest, Compact: *compact})
+	} else {
+		r = b.Solve()
+	}
+
+	if *asJSON {
+		e := json.NewEncoder(os.Stdout)
+		e.SetIndent("", "  ")
+		return e.Encode(r)
+	}
+
+	return nil
+}
+
+// strategyNames splits a comma separated list of strategy names
+func strategyNames(s string) []string {
+	names := []string{}
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Olden/sudoku-solver/batch"
+)
+
+func queryMode(args []string) error {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	requires := fs.String("requires", "", "strategy the puzzles can't be solved without")
+	hardest := fs.String("hardest", "", "hardest strategy allowed (default all)")
+	before := fs.Bool("before", false, "print the candidates just before the required strategy applies")
+	asJSON := fs.Bool("json", false, "print matching results as JSON lines")
+	steps := fs.Bool("steps", false, "include solving steps in JSON results")
+	workers := fs.Int("workers", 0, "number of puzzles solved at once (default GOMAXPROCS)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s query -requires strategy [flags] [file]\n\nPrints the puzzles, one per line, read from the file or stdin\nwhich can't be solved without the strategy.\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *requires == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	in, err := openInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	write := queryTextWriter(out)
+	if *asJSON {
+		write = batch.JSONWriter(out)
+	}
+	q := &batch.Query{Requires: *requires, Hardest: *hardest, Before: *before}
+
+	return batch.Solve(context.Background(), in, batch.Options{Workers: *workers, Steps: *steps, Query: q}, func(r batch.Result) error {
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "line %d: %s\n", r.Line, r.Error)
+			return nil
+		}
+		if !r.Match {
+			return nil
+		}
+		return write(r)
+	})
+}
+
+// queryTextWriter writes matching puzzles as tab separated lines: line
+// number, puzzle, hardest strategy, rating and the candidates before
+// the required strategy on a single line if asked for
+func queryTextWriter(w io.Writer) func(batch.Result) error {
+	return func(r batch.Result) error {
+		line := fmt.Sprintf("%d\t%s\t%s\t%.1f", r.Line, r.Puzzle, r.Result.HardestStrategy, r.Result.Rating.Max)
+		if r.Before != "" {
+			line += "\t" + strings.Join(strings.Fields(r.Before), " ")
+		}
+		_, err := fmt.Fprintln(w, line)
+		return err
+	}
+}
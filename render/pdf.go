@@ -0,0 +1,83 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+// digitWidth is the width of digits in Helvetica fonts per point of font size
+const digitWidth = 0.556
+
+// capHeight is the height of digits in Helvetica fonts per point of font size
+const capHeight = 0.718
+
+// pdfCanvas draws with PDF content stream operators
+type pdfCanvas struct {
+	b bytes.Buffer
+}
+
+func (c *pdfCanvas) rect(x, y, w, h float64, fill color.RGBA) {
+	fmt.Fprintf(&c.b, "%s rg %.2f %.2f %.2f %.2f re f\n", rgb(fill), x, size-y-h, w, h)
+}
+
+func (c *pdfCanvas) line(x1, y1, x2, y2, width float64, stroke color.RGBA) {
+	fmt.Fprintf(&c.b, "%s RG %.2f w 2 J %.2f %.2f m %.2f %.2f l S\n", rgb(stroke), width, x1, size-y1, x2, size-y2)
+}
+
+func (c *pdfCanvas) text(x, y, fontSize float64, bold bool, s string, fill color.RGBA) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	x -= float64(len(s)) * digitWidth * fontSize / 2
+	y += capHeight * fontSize / 2
+	fmt.Fprintf(&c.b, "BT %s rg /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", rgb(fill), font, fontSize, x, size-y, s)
+}
+
+func rgb(c color.RGBA) string {
+	return fmt.Sprintf("%.3f %.3f %.3f", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+}
+
+// PDF writes the boards as a PDF document, one board per page.
+// Only the standard Helvetica fonts are used, so nothing is embedded
+func PDF(w io.Writer, o Options, boards ...*solver.Board) error {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"", // pages, filled in when all pages are known
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold >>",
+	}
+
+	kids := bytes.Buffer{}
+	for _, b := range boards {
+		c := &pdfCanvas{}
+		drawBoard(c, b, o)
+
+		objects = append(objects, fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", c.b.Len(), c.b.String()))
+		objects = append(objects, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", size, size, len(objects)))
+		fmt.Fprintf(&kids, "%d 0 R ", len(objects))
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", bytes.TrimSpace(kids.Bytes()), len(boards))
+
+	doc := bytes.Buffer{}
+	doc.WriteString("%PDF-1.4\n")
+	offsets := []int{}
+	for i, obj := range objects {
+		offsets = append(offsets, doc.Len())
+		fmt.Fprintf(&doc, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := doc.Len()
+	fmt.Fprintf(&doc, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&doc, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	_, err := w.Write(doc.Bytes())
+	return err
+}
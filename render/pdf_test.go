@@ -0,0 +1,45 @@
+package render
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+func TestPDF(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+	b1 := solver.NewBoard(l, testPuzzle)
+	b2 := solver.NewBoard(l, testPuzzle)
+	w := &bytes.Buffer{}
+	if err := PDF(w, Options{Candidates: true}, b1, b2); err != nil {
+		t.Fatal(err)
+	}
+	doc := w.String()
+
+	if !strings.HasPrefix(doc, "%PDF-1.4\n") || !strings.HasSuffix(doc, "%%EOF\n") {
+		t.Errorf("PDF has no header or trailer")
+	}
+	if !strings.Contains(doc, "/Count 2") {
+		t.Errorf("PDF must have a page per board")
+	}
+
+	// every xref entry must point to its object
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(doc)
+	xref, _ := strconv.Atoi(m[1])
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(doc[xref:], -1)
+	if len(entries) != 8 {
+		t.Fatalf("PDF has %d objects, expected 8", len(entries))
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(e[1])
+		if !strings.HasPrefix(doc[off:], strconv.Itoa(i+1)+" 0 obj\n") {
+			t.Errorf("xref entry %d points to %q", i+1, doc[off:off+10])
+		}
+	}
+}
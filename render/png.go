@@ -0,0 +1,118 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+// rasterScale is the number of pixels per point
+const rasterScale = 2
+
+// glyphs is a 5x7 bitmap font for digits
+var glyphs = map[rune][7]string{
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+}
+
+type rasterCanvas struct {
+	img *image.RGBA
+}
+
+func (c *rasterCanvas) rect(x, y, w, h float64, fill color.RGBA) {
+	r := image.Rect(px(x), px(y), px(x+w), px(y+h))
+	draw.Draw(c.img, r, image.NewUniform(fill), image.Point{}, draw.Src)
+}
+
+func (c *rasterCanvas) line(x1, y1, x2, y2, width float64, stroke color.RGBA) {
+	half := width / 2
+	length := math.Hypot(x2-x1, y2-y1)
+	steps := int(length*rasterScale) + 1
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x, y := x1+t*(x2-x1), y1+t*(y2-y1)
+		c.rect(x-half, y-half, width, width, stroke)
+	}
+}
+
+func (c *rasterCanvas) text(x, y, size float64, bold bool, s string, fill color.RGBA) {
+	dot := int(math.Max(1, math.Round(capHeight*size*rasterScale/7)))
+	width := len(s)*6*dot - dot
+	left, top := px(x)-width/2, px(y)-7*dot/2
+
+	boldness := 0
+	if bold {
+		boldness = (dot + 1) / 2
+	}
+	for i, ch := range s {
+		for row, bits := range glyphs[ch] {
+			for col, bit := range bits {
+				if bit != '#' {
+					continue
+				}
+				r := image.Rect(0, 0, dot+boldness, dot).Add(image.Pt(left+(i*6+col)*dot, top+row*dot))
+				draw.Draw(c.img, r, image.NewUniform(fill), image.Point{}, draw.Src)
+			}
+		}
+	}
+}
+
+func px(v float64) int {
+	return int(math.Round(v * rasterScale))
+}
+
+// Image draws the board as a raster image
+func Image(o Options, b *solver.Board) *image.RGBA {
+	c := &rasterCanvas{image.NewRGBA(image.Rect(0, 0, px(size), px(size)))}
+	drawBoard(c, b, o)
+
+	return c.img
+}
+
+// PNG writes the board as a PNG image
+func PNG(w io.Writer, o Options, b *solver.Board) error {
+	return png.Encode(w, Image(o, b))
+}
+
+// Frames draws a frame for every step of a solving trace, highlighting the
+// step on the board before it, followed by a frame of the final board.
+// The board must be in the state before the first step, the steps are
+// applied to it
+func Frames(o Options, b *solver.Board, steps []solver.Step) []*image.RGBA {
+	r := []*image.RGBA{}
+	for i := range steps {
+		o.Step = &steps[i]
+		r = append(r, Image(o, b))
+		b.Apply(steps[i])
+	}
+	o.Step = nil
+
+	return append(r, Image(o, b))
+}
+
+// GIF writes the frames as an animated GIF, delay is the time
+// between frames in 100ths of a second
+func GIF(w io.Writer, frames []*image.RGBA, delay int) error {
+	g := &gif.GIF{}
+	for _, f := range frames {
+		p := image.NewPaletted(f.Bounds(), palette)
+		draw.Draw(p, p.Rect, f, image.Point{}, draw.Src)
+		g.Image = append(g.Image, p)
+		g.Delay = append(g.Delay, delay)
+	}
+
+	return gif.EncodeAll(w, g)
+}
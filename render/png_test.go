@@ -0,0 +1,59 @@
+package render
+
+import (
+	"bytes"
+	"image/gif"
+	"image/png"
+	"io/ioutil"
+	"log"
+	"testing"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+func TestPNG(t *testing.T) {
+	b := solver.NewBoard(log.New(ioutil.Discard, "", 0), testPuzzle)
+	h, _ := solver.NextHint(b, solver.HintFull)
+
+	w := &bytes.Buffer{}
+	if err := PNG(w, Options{Step: h.Step}, b); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != px(size) || img.Bounds().Dy() != px(size) {
+		t.Errorf("image size is: %v, expected: %d", img.Bounds(), px(size))
+	}
+
+	e := h.Step.Eliminations[0]
+	x, y := candidateCenter(e)
+	if c := img.At(px(x)-px(cellSize/6)+3, px(y)-px(cellSize/6)+3); c != eliminateColor {
+		t.Errorf("elimination %s is not highlighted: %v", e, c)
+	}
+}
+
+func TestGIF(t *testing.T) {
+	l := log.New(ioutil.Discard, "", 0)
+	b := solver.NewBoard(l, testPuzzle)
+	steps := b.Solve().Steps[:5]
+
+	frames := Frames(Options{}, solver.NewBoard(l, testPuzzle), steps)
+	if len(frames) != len(steps)+1 {
+		t.Fatalf("%d frames, expected %d", len(frames), len(steps)+1)
+	}
+
+	w := &bytes.Buffer{}
+	if err := GIF(w, frames, 100); err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Image) != len(frames) {
+		t.Errorf("GIF has %d frames, expected %d", len(g.Image), len(frames))
+	}
+}
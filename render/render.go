@@ -0,0 +1,168 @@
+// Package render draws boards and their candidate grids as SVG and PDF
+// for printing, and as PNG and animated GIF images for tutorials,
+// optionally highlighting solving steps.
+package render
+
+import (
+	"image/color"
+	"strconv"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+// Layout of the board in points
+const (
+	margin   = 20.0
+	cellSize = 40.0
+	size     = 2*margin + 9*cellSize
+
+	valueFont     = 24.0
+	candidateFont = 10.0
+)
+
+// Colors used to draw the board
+var (
+	black          = color.RGBA{0, 0, 0, 255}
+	white          = color.RGBA{255, 255, 255, 255}
+	solvedColor    = color.RGBA{31, 78, 156, 255}
+	patternColor   = color.RGBA{207, 226, 255, 255}
+	digitColor     = color.RGBA{183, 228, 199, 255}
+	eliminateColor = color.RGBA{248, 180, 180, 255}
+	eliminateDigit = color.RGBA{192, 0, 0, 255}
+	placeColor     = color.RGBA{216, 243, 220, 255}
+	strongLink     = color.RGBA{0, 128, 0, 255}
+	weakLink       = color.RGBA{230, 120, 0, 255}
+)
+
+// palette contains all the colors used to draw the board
+var palette = color.Palette{
+	black, white, solvedColor, patternColor, digitColor,
+	eliminateColor, eliminateDigit, placeColor, strongLink, weakLink,
+}
+
+// Options control what is drawn
+type Options struct {
+	// Candidates draws pencil marks of unsolved cells
+	Candidates bool
+	// Step highlights the pattern cells and digits, links, eliminations and
+	// placements of a step. The board must be in the state before the step,
+	// candidates are always drawn
+	Step *solver.Step
+}
+
+// canvas is a drawing surface with the origin in the top left corner
+type canvas interface {
+	rect(x, y, w, h float64, fill color.RGBA)
+	line(x1, y1, x2, y2, width float64, stroke color.RGBA)
+	// text draws digits centered at x, y
+	text(x, y, size float64, bold bool, s string, fill color.RGBA)
+}
+
+type candidateKey struct {
+	solver.Position
+	digit int
+}
+
+// drawBoard draws the board on the canvas
+func drawBoard(c canvas, b *solver.Board, o Options) {
+	c.rect(0, 0, size, size, white)
+
+	pattern := map[solver.Position]bool{}
+	digits := map[int]bool{}
+	eliminations := map[candidateKey]bool{}
+	placements := map[solver.Position]bool{}
+	if o.Step != nil {
+		for _, p := range o.Step.Cells {
+			pattern[p] = true
+		}
+		for _, d := range o.Step.Digits {
+			digits[d] = true
+		}
+		for _, e := range o.Step.Eliminations {
+			eliminations[candidateKey{e.Position, e.Digit}] = true
+		}
+		for _, p := range o.Step.Placements {
+			placements[p.Position] = true
+		}
+	}
+
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 9; x++ {
+			p := solver.Position{X: x, Y: y}
+			left, top := margin+float64(x)*cellSize, margin+float64(y)*cellSize
+
+			switch {
+			case pattern[p]:
+				c.rect(left, top, cellSize, cellSize, patternColor)
+			case placements[p]:
+				c.rect(left, top, cellSize, cellSize, placeColor)
+			}
+
+			candidates := b.Candidates(p)
+			if len(candidates) == 1 {
+				fill := solvedColor
+				if b.IsGiven(p) {
+					fill = black
+				}
+				c.text(left+cellSize/2, top+cellSize/2, valueFont, b.IsGiven(p), strconv.Itoa(candidates[0]), fill)
+				continue
+			}
+			if !o.Candidates && o.Step == nil {
+				continue
+			}
+
+			for d := 1; d <= 9; d++ {
+				sx := left + float64((d-1)%3)*cellSize/3
+				sy := top + float64((d-1)/3)*cellSize/3
+				fill := black
+				switch {
+				case eliminations[candidateKey{p, d}]:
+					c.rect(sx+1, sy+1, cellSize/3-2, cellSize/3-2, eliminateColor)
+					fill = eliminateDigit
+				case pattern[p] && digits[d] && contains(candidates, d):
+					c.rect(sx+1, sy+1, cellSize/3-2, cellSize/3-2, digitColor)
+				case !contains(candidates, d):
+					continue
+				}
+				c.text(sx+cellSize/6, sy+cellSize/6, candidateFont, false, strconv.Itoa(d), fill)
+			}
+		}
+	}
+
+	if o.Step != nil {
+		for _, l := range o.Step.Links {
+			stroke := weakLink
+			if l.Strong {
+				stroke = strongLink
+			}
+			x1, y1 := candidateCenter(l.From)
+			x2, y2 := candidateCenter(l.To)
+			c.line(x1, y1, x2, y2, 1.5, stroke)
+		}
+	}
+
+	for i := 0; i <= 9; i++ {
+		width := 1.0
+		if i%3 == 0 {
+			width = 3
+		}
+		offset := margin + float64(i)*cellSize
+		c.line(offset, margin, offset, size-margin, width, black)
+		c.line(margin, offset, size-margin, offset, width, black)
+	}
+}
+
+func candidateCenter(c solver.Candidate) (float64, float64) {
+	return margin + float64(c.X)*cellSize + (float64((c.Digit-1)%3)+0.5)*cellSize/3,
+		margin + float64(c.Y)*cellSize + (float64((c.Digit-1)/3)+0.5)*cellSize/3
+}
+
+func contains(ds []int, d int) bool {
+	for _, v := range ds {
+		if v == d {
+			return true
+		}
+	}
+
+	return false
+}
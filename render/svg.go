@@ -0,0 +1,48 @@
+package render
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+type svgCanvas struct {
+	b strings.Builder
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, fill color.RGBA) {
+	fmt.Fprintf(&c.b, "<rect x=\"%g\" y=\"%g\" width=\"%g\" height=\"%g\" fill=\"%s\"/>\n", x, y, w, h, hex(fill))
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2, width float64, stroke color.RGBA) {
+	fmt.Fprintf(&c.b, "<line x1=\"%g\" y1=\"%g\" x2=\"%g\" y2=\"%g\" stroke=\"%s\" stroke-width=\"%g\" stroke-linecap=\"square\"/>\n", x1, y1, x2, y2, hex(stroke), width)
+}
+
+func (c *svgCanvas) text(x, y, size float64, bold bool, s string, fill color.RGBA) {
+	weight := "normal"
+	if bold {
+		weight = "bold"
+	}
+	fmt.Fprintf(&c.b, "<text x=\"%g\" y=\"%g\" font-size=\"%g\" font-weight=\"%s\" fill=\"%s\">%s</text>\n", x, y, size, weight, hex(fill), s)
+}
+
+// SVG writes the board as an SVG image
+func SVG(w io.Writer, o Options, b *solver.Board) error {
+	c := &svgCanvas{}
+	drawBoard(c, b, o)
+
+	_, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">
+<g font-family="Helvetica, Arial, sans-serif" text-anchor="middle" dominant-baseline="central">
+%s</g>
+</svg>
+`, size, size, size, size, c.b.String())
+
+	return err
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
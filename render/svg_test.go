@@ -0,0 +1,49 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+const testPuzzle = "000000001000000023004005000000002000010000400360070000000610000005000800007030000"
+
+func TestSVG(t *testing.T) {
+	b := solver.NewBoard(log.New(ioutil.Discard, "", 0), testPuzzle)
+	w := &bytes.Buffer{}
+	if err := SVG(w, Options{}, b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := xml.Unmarshal(w.Bytes(), &struct{}{}); err != nil {
+		t.Errorf("SVG is not valid XML: %s", err)
+	}
+	if n := strings.Count(w.String(), `font-weight="bold"`); n != 17 {
+		t.Errorf("SVG has %d bold digits, expected 17 givens", n)
+	}
+	if n := strings.Count(w.String(), "<line"); n != 20 {
+		t.Errorf("SVG has %d grid lines, expected 20", n)
+	}
+}
+
+func TestSVGStep(t *testing.T) {
+	b := solver.NewBoard(log.New(ioutil.Discard, "", 0), testPuzzle)
+	h, _ := solver.NextHint(b, solver.HintFull)
+
+	w := &bytes.Buffer{}
+	if err := SVG(w, Options{Step: h.Step}, b); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(w.String(), hex(eliminateDigit)); n != len(h.Step.Eliminations) {
+		t.Errorf("SVG has %d eliminations, expected %d", n, len(h.Step.Eliminations))
+	}
+	if !strings.Contains(w.String(), hex(patternColor)) {
+		t.Errorf("SVG must highlight pattern cells")
+	}
+}
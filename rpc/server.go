@@ -0,0 +1,187 @@
+// Package rpc implements the gRPC Solver service described in solver.proto
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+// Server is the Solver service backed by the solver package
+type Server struct {
+	UnimplementedSolverServer
+}
+
+// NewServer creates the Solver service, register it with RegisterSolverServer
+func NewServer() *Server {
+	return &Server{}
+}
+
+func parse(puzzle string) (*solver.Board, error) {
+	b, err := solver.Parse(nil, puzzle)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return b, nil
+}
+
+// solve solves the board using all strategies until ctx is done
+func solve(ctx context.Context, b *solver.Board) (solver.Result, error) {
+	res := b.SolveContext(ctx, solver.SolveOptions{})
+	if err := ctx.Err(); err != nil {
+		return res, status.FromContextError(err).Err()
+	}
+
+	return res, nil
+}
+
+// Solve solves the puzzle using all strategies
+func (s *Server) Solve(ctx context.Context, r *SolveRequest) (*SolveResponse, error) {
+	b, err := parse(r.Puzzle)
+	if err != nil {
+		return nil, err
+	}
+	puzzle := toBoard(b)
+	res, err := solve(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []*Step{}
+	for _, st := range res.Steps {
+		steps = append(steps, toStep(st))
+	}
+
+	return &SolveResponse{
+		Puzzle:          puzzle,
+		Board:           toBoard(b),
+		Solved:          res.Solved,
+		HardestStrategy: res.HardestStrategy,
+		Rating:          toRating(res.Rating),
+		Steps:           steps,
+	}, nil
+}
+
+// SolveSteps solves the puzzle and sends each step as soon as it's found
+func (s *Server) SolveSteps(r *SolveRequest, stream Solver_SolveStepsServer) error {
+	b, err := parse(r.Puzzle)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.SolveSteps(func(st solver.Step) error {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		return stream.Send(toStep(st))
+	})
+
+	return err
+}
+
+// Rate rates the puzzle
+func (s *Server) Rate(ctx context.Context, r *RateRequest) (*RateResponse, error) {
+	b, err := parse(r.Puzzle)
+	if err != nil {
+		return nil, err
+	}
+	res, err := solve(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateResponse{Rating: toRating(res.Rating), HardestStrategy: res.HardestStrategy}, nil
+}
+
+// Generate creates a puzzle of the requested level. It keeps generating
+// until a puzzle of the level is found or ctx is done
+func (s *Server) Generate(ctx context.Context, r *GenerateRequest) (*GenerateResponse, error) {
+	seed := time.Now().UnixNano()
+	if r.Seed != nil {
+		seed = *r.Seed
+	}
+
+	p, res, err := solver.GenerateLevel(ctx, rand.New(rand.NewSource(seed)), r.Level)
+	if errors.Is(err, solver.ErrUnknownLevel) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+
+	return &GenerateResponse{Puzzle: p, Rating: toRating(res.Rating), HardestStrategy: res.HardestStrategy}, nil
+}
+
+func toBoard(b *solver.Board) *Board {
+	r := &Board{}
+	grid := strings.Builder{}
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 9; x++ {
+			p := solver.Position{X: x, Y: y}
+			c := &Cell{Given: b.IsGiven(p)}
+			for _, d := range b.Candidates(p) {
+				c.Candidates = append(c.Candidates, int32(d))
+			}
+			if len(c.Candidates) == 1 {
+				c.Value = c.Candidates[0]
+				grid.WriteByte(byte('0' + c.Value))
+			} else {
+				grid.WriteByte('.')
+			}
+			r.Cells = append(r.Cells, c)
+		}
+	}
+	r.Grid = grid.String()
+
+	return r
+}
+
+func toRating(r solver.Rating) *Rating {
+	return &Rating{Max: r.Max, Total: r.Total, Steps: int32(r.Steps), Solved: r.Solved, Level: r.Level()}
+}
+
+func toPosition(p solver.Position) *Position {
+	return &Position{X: int32(p.X), Y: int32(p.Y)}
+}
+
+func toCandidate(c solver.Candidate) *Candidate {
+	return &Candidate{Position: toPosition(c.Position), Digit: int32(c.Digit)}
+}
+
+func toCandidates(cs []solver.Candidate) []*Candidate {
+	r := []*Candidate{}
+	for _, c := range cs {
+		r = append(r, toCandidate(c))
+	}
+
+	return r
+}
+
+func toStep(s solver.Step) *Step {
+	r := &Step{
+		Strategy:     s.Strategy,
+		Unit:         s.Unit,
+		Eliminations: toCandidates(s.Eliminations),
+		Placements:   toCandidates(s.Placements),
+		Description:  s.Description,
+	}
+	for _, p := range s.Cells {
+		r.Cells = append(r.Cells, toPosition(p))
+	}
+	for _, d := range s.Digits {
+		r.Digits = append(r.Digits, int32(d))
+	}
+	for _, l := range s.Links {
+		r.Links = append(r.Links, &Link{From: toCandidate(l.From), To: toCandidate(l.To), Strong: l.Strong})
+	}
+
+	return r
+}
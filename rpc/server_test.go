@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+const testPuzzle = "000000001000000023004005000000002000010000400360070000000610000005000800007030000"
+
+func newClient(t *testing.T) SolverClient {
+	l := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	RegisterSolverServer(g, NewServer())
+	go g.Serve(l)
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return NewSolverClient(conn)
+}
+
+func TestSolve(t *testing.T) {
+	c := newClient(t)
+
+	r, err := c.Solve(context.Background(), &SolveRequest{Puzzle: testPuzzle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.Solved || r.HardestStrategy != "hidden pairs" || r.Rating.Level != solver.LevelMedium || len(r.Steps) == 0 {
+		t.Errorf("unexpected response: %v", r)
+	}
+	if len(r.Board.Cells) != 81 || r.Puzzle.Cells[8].Value != 1 || !r.Puzzle.Cells[8].Given || r.Puzzle.Cells[0].Value != 0 {
+		t.Errorf("unexpected boards: %v, %v", r.Puzzle, r.Board)
+	}
+
+	_, err = c.Solve(context.Background(), &SolveRequest{Puzzle: "123"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument, got %v", err)
+	}
+}
+
+func TestSolveCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s := NewServer()
+	if _, err := s.Solve(ctx, &SolveRequest{Puzzle: testPuzzle}); status.Code(err) != codes.Canceled {
+		t.Errorf("solve: expected canceled, got %v", err)
+	}
+	if _, err := s.Rate(ctx, &RateRequest{Puzzle: testPuzzle}); status.Code(err) != codes.Canceled {
+		t.Errorf("rate: expected canceled, got %v", err)
+	}
+}
+
+func TestSolveSteps(t *testing.T) {
+	c := newClient(t)
+
+	stream, err := c.SolveSteps(context.Background(), &SolveRequest{Puzzle: testPuzzle})
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps := []*Step{}
+	for {
+		s, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		steps = append(steps, s)
+	}
+
+	expected := solver.NewBoard(nil, testPuzzle).Solve().Steps
+	if len(steps) != len(expected) {
+		t.Fatalf("streamed %d steps, expected %d", len(steps), len(expected))
+	}
+	for i, s := range steps {
+		if s.Description != expected[i].Description {
+			t.Errorf("step %d: %q, expected %q", i, s.Description, expected[i].Description)
+		}
+	}
+}
+
+func TestRateAndGenerate(t *testing.T) {
+	c := newClient(t)
+
+	r, err := c.Rate(context.Background(), &RateRequest{Puzzle: testPuzzle})
+	if err != nil || r.Rating.Max != 3.4 || r.HardestStrategy != "hidden pairs" {
+		t.Errorf("unexpected rating: %v, error: %v", r, err)
+	}
+
+	seed := int64(1)
+	g, err := c.Generate(context.Background(), &GenerateRequest{Level: solver.LevelEasy, Seed: &seed})
+	if err != nil || g.Rating.Level != solver.LevelEasy || len(g.Puzzle) != 81 {
+		t.Errorf("unexpected puzzle: %v, error: %v", g, err)
+	}
+
+	_, err = c.Generate(context.Background(), &GenerateRequest{Level: "Impossible"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument, got %v", err)
+	}
+}
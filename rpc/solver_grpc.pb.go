@@ -0,0 +1,263 @@
+// Solver service of the Sudoku solver.
+//
+// Regenerate the Go code with:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	    --go-grpc_out=. --go-grpc_opt=paths=source_relative rpc/solver.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.24.4
+// source: rpc/solver.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Solver_Solve_FullMethodName      = "/sudoku.v1.Solver/Solve"
+	Solver_SolveSteps_FullMethodName = "/sudoku.v1.Solver/SolveSteps"
+	Solver_Rate_FullMethodName       = "/sudoku.v1.Solver/Rate"
+	Solver_Generate_FullMethodName   = "/sudoku.v1.Solver/Generate"
+)
+
+// SolverClient is the client API for Solver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SolverClient interface {
+	// Solve solves the puzzle using all strategies
+	Solve(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (*SolveResponse, error)
+	// SolveSteps solves the puzzle and streams the steps as they are found
+	SolveSteps(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (Solver_SolveStepsClient, error)
+	// Rate rates the puzzle
+	Rate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error)
+	// Generate creates a puzzle with a unique solution
+	Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error)
+}
+
+type solverClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSolverClient(cc grpc.ClientConnInterface) SolverClient {
+	return &solverClient{cc}
+}
+
+func (c *solverClient) Solve(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (*SolveResponse, error) {
+	out := new(SolveResponse)
+	err := c.cc.Invoke(ctx, Solver_Solve_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solverClient) SolveSteps(ctx context.Context, in *SolveRequest, opts ...grpc.CallOption) (Solver_SolveStepsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Solver_ServiceDesc.Streams[0], Solver_SolveSteps_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &solverSolveStepsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Solver_SolveStepsClient interface {
+	Recv() (*Step, error)
+	grpc.ClientStream
+}
+
+type solverSolveStepsClient struct {
+	grpc.ClientStream
+}
+
+func (x *solverSolveStepsClient) Recv() (*Step, error) {
+	m := new(Step)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *solverClient) Rate(ctx context.Context, in *RateRequest, opts ...grpc.CallOption) (*RateResponse, error) {
+	out := new(RateResponse)
+	err := c.cc.Invoke(ctx, Solver_Rate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solverClient) Generate(ctx context.Context, in *GenerateRequest, opts ...grpc.CallOption) (*GenerateResponse, error) {
+	out := new(GenerateResponse)
+	err := c.cc.Invoke(ctx, Solver_Generate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SolverServer is the server API for Solver service.
+// All implementations must embed UnimplementedSolverServer
+// for forward compatibility
+type SolverServer interface {
+	// Solve solves the puzzle using all strategies
+	Solve(context.Context, *SolveRequest) (*SolveResponse, error)
+	// SolveSteps solves the puzzle and streams the steps as they are found
+	SolveSteps(*SolveRequest, Solver_SolveStepsServer) error
+	// Rate rates the puzzle
+	Rate(context.Context, *RateRequest) (*RateResponse, error)
+	// Generate creates a puzzle with a unique solution
+	Generate(context.Context, *GenerateRequest) (*GenerateResponse, error)
+	mustEmbedUnimplementedSolverServer()
+}
+
+// UnimplementedSolverServer must be embedded to have forward compatible implementations.
+type UnimplementedSolverServer struct {
+}
+
+func (UnimplementedSolverServer) Solve(context.Context, *SolveRequest) (*SolveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Solve not implemented")
+}
+func (UnimplementedSolverServer) SolveSteps(*SolveRequest, Solver_SolveStepsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SolveSteps not implemented")
+}
+func (UnimplementedSolverServer) Rate(context.Context, *RateRequest) (*RateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rate not implemented")
+}
+func (UnimplementedSolverServer) Generate(context.Context, *GenerateRequest) (*GenerateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Generate not implemented")
+}
+func (UnimplementedSolverServer) mustEmbedUnimplementedSolverServer() {}
+
+// UnsafeSolverServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SolverServer will
+// result in compilation errors.
+type UnsafeSolverServer interface {
+	mustEmbedUnimplementedSolverServer()
+}
+
+func RegisterSolverServer(s grpc.ServiceRegistrar, srv SolverServer) {
+	s.RegisterService(&Solver_ServiceDesc, srv)
+}
+
+func _Solver_Solve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SolveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolverServer).Solve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Solver_Solve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolverServer).Solve(ctx, req.(*SolveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Solver_SolveSteps_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SolveRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SolverServer).SolveSteps(m, &solverSolveStepsServer{stream})
+}
+
+type Solver_SolveStepsServer interface {
+	Send(*Step) error
+	grpc.ServerStream
+}
+
+type solverSolveStepsServer struct {
+	grpc.ServerStream
+}
+
+func (x *solverSolveStepsServer) Send(m *Step) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Solver_Rate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolverServer).Rate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Solver_Rate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolverServer).Rate(ctx, req.(*RateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Solver_Generate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolverServer).Generate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Solver_Generate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolverServer).Generate(ctx, req.(*GenerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Solver_ServiceDesc is the grpc.ServiceDesc for Solver service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Solver_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sudoku.v1.Solver",
+	HandlerType: (*SolverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Solve",
+			Handler:    _Solver_Solve_Handler,
+		},
+		{
+			MethodName: "Rate",
+			Handler:    _Solver_Rate_Handler,
+		},
+		{
+			MethodName: "Generate",
+			Handler:    _Solver_Generate_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SolveSteps",
+			Handler:       _Solver_SolveSteps_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc/solver.proto",
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/Olden/sudoku-solver/rpc"
+	"github.com/Olden/sudoku-solver/server"
+)
+
+func serveMode(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	grpcAddr := fs.String("grpc", "", "address to serve the gRPC service on, disabled if empty")
+	timeout := fs.Duration("timeout", server.DefaultTimeout, "time limit of a request")
+	maxBody := fs.Int64("max-body", server.DefaultMaxBodyBytes, "size limit of a request body in bytes")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s serve [flags]\n\nServes the HTTP JSON API and optionally the gRPC service.\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *grpcAddr != "" {
+		l, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			return err
+		}
+		g := grpc.NewServer()
+		rpc.RegisterSolverServer(g, rpc.NewServer())
+		log.Printf("gRPC listening on %s", *grpcAddr)
+		go func() {
+			log.Fatal(g.Serve(l))
+		}()
+	}
+
+	s := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(server.Options{MaxBodyBytes: *maxBody, Timeout: *timeout}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("Listening on %s", *addr)
+
+	return s.ListenAndServe()
+}
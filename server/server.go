@@ -0,0 +1,289 @@
+// Package server exposes the solver as an HTTP JSON API.
+//
+// All endpoints accept POST requests with a JSON Request body and
+// respond with JSON. Errors are returned as {"error": "..."}.
+//
+//	/solve     solves the puzzle, responds with solver.Result
+//	/rate      rates the puzzle
+//	/hint      finds the next step, level is "strategy", "region" or "full"
+//	/validate  checks the puzzle has a unique solution and if so reports
+//	           mistakes of the player's state against it
+//	/generate  generates a puzzle, optionally of the given level
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+// Default limits
+const (
+	DefaultMaxBodyBytes = 64 << 10
+	DefaultTimeout      = 10 * time.Second
+)
+
+// Options configure the server
+type Options struct {
+	// MaxBodyBytes limits the size of request bodies
+	MaxBodyBytes int64
+	// Timeout limits the time to handle a request
+	Timeout time.Duration
+}
+
+// Request is the body of all requests
+type Request struct {
+	// Puzzle is a puzzle string or a candidate grid
+	Puzzle string `json:"puzzle"`
+	// State is the player's board for /validate, a puzzle string or a candidate grid
+	State string `json:"state,omitempty"`
+	// Level is the disclosure level for /hint and the difficulty level for /generate
+	Level string `json:"level,omitempty"`
+	// Seed makes /generate reproducible
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// RateResponse is the response of /rate
+type RateResponse struct {
+	Rating          solver.Rating `json:"rating"`
+	HardestStrategy string        `json:"hardest_strategy"`
+}
+
+// HintResponse is the response of /hint
+type HintResponse struct {
+	Found bool `json:"found"`
+	*solver.Hint
+}
+
+// ValidateResponse is the response of /validate
+type ValidateResponse struct {
+	// Valid reports whether the puzzle has a unique solution
+	Valid bool `json:"valid"`
+	// Solutions is the number of solutions, 2 stands for two or more
+	Solutions int              `json:"solutions"`
+	Mistakes  []solver.Mistake `json:"mistakes,omitempty"`
+}
+
+// GenerateResponse is the response of /generate
+type GenerateResponse struct {
+	Puzzle          string        `json:"puzzle"`
+	Rating          solver.Rating `json:"rating"`
+	HardestStrategy string        `json:"hardest_strategy"`
+}
+
+// statusError is an error with an HTTP status
+type statusError struct {
+	status int
+	msg    string
+}
+
+func (e statusError) Error() string {
+	return e.msg
+}
+
+func badRequest(err error) error {
+	return statusError{http.StatusBadRequest, err.Error()}
+}
+
+var hintLevels = map[string]solver.HintLevel{
+	"":         solver.HintStrategy,
+	"strategy": solver.HintStrategy,
+	"region":   solver.HintRegion,
+	"full":     solver.HintFull,
+}
+
+type handlerFunc func(ctx context.Context, r Request) (interface{}, error)
+
+type server struct {
+	o Options
+}
+
+// New creates the API handler
+func New(o Options) http.Handler {
+	if o.MaxBodyBytes <= 0 {
+		o.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+	s := &server{o}
+
+	mux := http.NewServeMux()
+	mux.Handle("/solve", s.endpoint(solve))
+	mux.Handle("/rate", s.endpoint(rate))
+	mux.Handle("/hint", s.endpoint(hint))
+	mux.Handle("/validate", s.endpoint(validate))
+	mux.Handle("/generate", s.endpoint(generate))
+
+	return mux
+}
+
+func (s *server) endpoint(h handlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, statusError{http.StatusMethodNotAllowed, "method not allowed"})
+			return
+		}
+
+		req := Request{}
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.o.MaxBodyBytes)).Decode(&req); err != nil {
+			if tooLarge := (*http.MaxBytesError)(nil); errors.As(err, &tooLarge) {
+				writeError(w, statusError{http.StatusRequestEntityTooLarge, err.Error()})
+				return
+			}
+			writeError(w, badRequest(fmt.Errorf("invalid request: %s", err)))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), s.o.Timeout)
+		defer cancel()
+
+		type result struct {
+			v   interface{}
+			err error
+		}
+		done := make(chan result, 1)
+		go func() {
+			defer func() {
+				if err := recover(); err != nil {
+					done <- result{nil, statusError{http.StatusInternalServerError, fmt.Sprint(err)}}
+				}
+			}()
+			v, err := h(ctx, req)
+			done <- result{v, err}
+		}()
+
+		select {
+		case res := <-done:
+			if res.err != nil {
+				writeError(w, res.err)
+				return
+			}
+			writeJSON(w, http.StatusOK, res.v)
+		case <-ctx.Done():
+			writeError(w, statusError{http.StatusServiceUnavailable, "request timed out"})
+		}
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if se, ok := err.(statusError); ok {
+		status = se.status
+	}
+
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func parse(puzzle string) (*solver.Board, error) {
+	b, err := solver.Parse(nil, puzzle)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	return b, nil
+}
+
+func solve(ctx context.Context, r Request) (interface{}, error) {
+	b, err := parse(r.Puzzle)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.SolveContext(ctx, solver.SolveOptions{}), nil
+}
+
+func rate(ctx context.Context, r Request) (interface{}, error) {
+	b, err := parse(r.Puzzle)
+	if err != nil {
+		return nil, err
+	}
+	res := b.SolveContext(ctx, solver.SolveOptions{})
+
+	return RateResponse{res.Rating, res.HardestStrategy}, nil
+}
+
+func hint(ctx context.Context, r Request) (interface{}, error) {
+	level, ok := hintLevels[r.Level]
+	if !ok {
+		return nil, badRequest(fmt.Errorf("unknown hint level %q", r.Level))
+	}
+	b, err := parse(r.Puzzle)
+	if err != nil {
+		return nil, err
+	}
+
+	h, found, err := solver.NextHintContext(ctx, b, level)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return HintResponse{}, nil
+	}
+
+	return HintResponse{true, &h}, nil
+}
+
+func validate(ctx context.Context, r Request) (interface{}, error) {
+	givens, err := solver.ParseGivens(r.Puzzle)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	n, err := solver.CountSolutionsContext(ctx, givens, 2)
+	if err != nil {
+		return nil, err
+	}
+	res := ValidateResponse{Solutions: n}
+	res.Valid = res.Solutions == 1
+	// mistakes are only known against a unique solution
+	if r.State == "" || !res.Valid {
+		return res, nil
+	}
+
+	b, err := parse(r.State)
+	if err != nil {
+		return nil, err
+	}
+	solution, _, err := solver.SolveBruteForceContext(ctx, givens)
+	if err != nil {
+		return nil, err
+	}
+	digits := make([]byte, 81)
+	for i, v := range solution {
+		digits[i] = byte('0' + v)
+	}
+	res.Mistakes = b.Mistakes(string(digits))
+
+	return res, nil
+}
+
+func generate(ctx context.Context, r Request) (interface{}, error) {
+	seed := time.Now().UnixNano()
+	if r.Seed != nil {
+		seed = *r.Seed
+	}
+
+	p, res, err := solver.GenerateLevel(ctx, rand.New(rand.NewSource(seed)), r.Level)
+	if errors.Is(err, solver.ErrUnknownLevel) {
+		return nil, badRequest(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return GenerateResponse{p, res.Rating, res.HardestStrategy}, nil
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Olden/sudoku-solver/solver"
+)
+
+const testPuzzle = "000000001000000023004005000000002000010000400360070000000610000005000800007030000"
+
+func post(t *testing.T, h http.Handler, path, body string, v interface{}) int {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+
+	if v != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s: invalid response %q: %s", path, w.Body.String(), err)
+		}
+	}
+
+	return w.Code
+}
+
+func TestSolve(t *testing.T) {
+	h := New(Options{})
+
+	r := solver.Result{}
+	if code := post(t, h, "/solve", `{"puzzle": "`+testPuzzle+`"}`, &r); code != http.StatusOK || !r.Solved {
+		t.Errorf("status: %d, result: %+v", code, r)
+	}
+
+	e := map[string]string{}
+	if code := post(t, h, "/solve", `{"puzzle": "123"}`, &e); code != http.StatusBadRequest || e["error"] != "puzzle must have 81 cells, got 3" {
+		t.Errorf("status: %d, error: %v", code, e)
+	}
+}
+
+func TestRate(t *testing.T) {
+	r := RateResponse{}
+	if code := post(t, New(Options{}), "/rate", `{"puzzle": "`+testPuzzle+`"}`, &r); code != http.StatusOK || r.Rating.Max != 3.4 || r.HardestStrategy != "hidden pairs" {
+		t.Errorf("status: %d, rating: %+v", code, r)
+	}
+}
+
+func TestHint(t *testing.T) {
+	h := New(Options{})
+
+	r := map[string]interface{}{}
+	if code := post(t, h, "/hint", `{"puzzle": "`+testPuzzle+`", "level": "region"}`, &r); code != http.StatusOK || r["found"] != true || r["region"] == nil || r["step"] != nil {
+		t.Errorf("status: %d, hint: %v", code, r)
+	}
+
+	if code := post(t, h, "/hint", `{"puzzle": "`+testPuzzle+`", "level": "all"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("unknown level status: %d", code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	state := "3" + testPuzzle[1:]
+	r := ValidateResponse{}
+	if code := post(t, New(Options{}), "/validate", `{"puzzle": "`+testPuzzle+`", "state": "`+state+`"}`, &r); code != http.StatusOK || !r.Valid || r.Solutions != 1 {
+		t.Errorf("status: %d, response: %+v", code, r)
+	}
+	if len(r.Mistakes) != 1 || r.Mistakes[0].Kind != solver.MistakeWrongValue || r.Mistakes[0].Digit != 3 {
+		t.Errorf("mistakes: %+v, expected wrong value 3 in A1", r.Mistakes)
+	}
+
+	puzzle := "1" + strings.Repeat("0", 80)
+	r = ValidateResponse{}
+	if code := post(t, New(Options{}), "/validate", `{"puzzle": "`+puzzle+`", "state": "`+state+`"}`, &r); code != http.StatusOK || r.Valid || r.Solutions != 2 || len(r.Mistakes) != 0 {
+		t.Errorf("puzzle without a unique solution must not report mistakes, status: %d, response: %+v", code, r)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	r := GenerateResponse{}
+	if code := post(t, New(Options{}), "/generate", `{"seed": 1, "level": "Easy"}`, &r); code != http.StatusOK || r.Rating.Level() != solver.LevelEasy {
+		t.Errorf("status: %d, response: %+v", code, r)
+	}
+
+	givens, err := solver.ParseGivens(r.Puzzle)
+	if err != nil || solver.CountSolutions(givens, 2) != 1 {
+		t.Errorf("generated puzzle %s has no unique solution", r.Puzzle)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	h := New(Options{MaxBodyBytes: 100, Timeout: 10 * time.Millisecond})
+
+	if code := post(t, h, "/solve", `{"puzzle": "`+testPuzzle+`      "}`, nil); code != http.StatusRequestEntityTooLarge {
+		t.Errorf("too large request status: %d", code)
+	}
+	if code := post(t, h, "/generate", `{"level": "Expert"}`, nil); code != http.StatusServiceUnavailable {
+		t.Errorf("timed out request status: %d", code)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/solve", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET status: %d", w.Code)
+	}
+}
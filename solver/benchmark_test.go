@@ -0,0 +1,54 @@
+package solver
+
+import (
+	"context"
+	"testing"
+)
+
+// benchmarkPuzzles are solved by the benchmarks
+var benchmarkPuzzles = map[string]string{
+	"medium": mediumPuzzle,
+	"stuck":  hardPuzzle,
+}
+
+func BenchmarkSolve(b *testing.B) {
+	for name, p := range benchmarkPuzzles {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewBoard(nil, p).Solve()
+			}
+		})
+	}
+}
+
+func BenchmarkSolvePropagate(b *testing.B) {
+	for name, p := range benchmarkPuzzles {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewBoard(nil, p).SolveContext(context.Background(), SolveOptions{Propagate: true})
+			}
+		})
+	}
+}
+
+func BenchmarkStrategies(b *testing.B) {
+	board := NewBoard(nil, benchmarkPuzzles["stuck"])
+	for _, s := range board.strategies[1:] {
+		s := s
+		b.Run(s.Name(), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.Apply(board)
+			}
+		})
+	}
+}
+
+func BenchmarkCandidates(b *testing.B) {
+	board := NewBoard(nil, benchmarkPuzzles["medium"])
+	for i := 0; i < b.N; i++ {
+		for _, c := range board.fc {
+			c.isCandidate(5)
+			c.value()
+		}
+	}
+}
@@ -1,71 +1,113 @@
 package solver
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"math"
 	"strconv"
 	"strings"
-
-	funk "github.com/thoas/go-funk"
-	"gonum.org/v1/gonum/stat/combin"
 )
 
 type Board struct {
 	log        *log.Logger
 	c          [][]*Cell
 	fc         []*Cell
-	strategies []*Strategy
+	strategies []Strategy
+	rating     Rating
+	// puzzle is the board as it was created
+	puzzle string
+	// onStep is called with each applied step, solving stops if it returns false
+	onStep func(Step) bool
+	// units and peers are the cells of unitCells and peerCells
+	units [27][]*Cell
+	peers [81][]*Cell
+	// places[u][d-1] are the cells of unit u where digit d is a candidate
+	places [27][9]cellSet
+	// history are the applied steps for Undo
+	history []Step
 }
 
 var UnitType = []string{"row", "column", "block"}
 
+// NewBoard creates a board from a puzzle string, see Parse.
+// The logger l receives the solving log, it can be nil to disable logging.
+// NewBoard panics if the string is not a valid puzzle
 func NewBoard(l *log.Logger, cells string) *Board {
-	if len(cells) != 81 {
-		panic(errors.New("invalid Sudoku board"))
+	b, err := Parse(l, cells)
+	if err != nil {
+		panic(fmt.Errorf("invalid Sudoku board: %s", err))
 	}
 
-	c := make([]int, 81)
-	for i, ch := range strings.SplitAfter(cells, "") {
-		v, err := strconv.Atoi(ch)
-		if err != nil {
-			c[i] = 0
-		}
+	return b
+}
 
-		c[i] = v
+// NewBoardFromCandidates creates a board from a full candidate grid,
+// e.g. pencil marks entered by a player. candidates holds 81 cells row by row,
+// a solved cell has a single candidate
+func NewBoardFromCandidates(l *log.Logger, candidates [][]float64) *Board {
+	if len(candidates) != 81 {
+		panic(errors.New("invalid Sudoku board"))
 	}
+	for _, c := range candidates {
+		var m mask
+		for _, d := range c {
+			if d < 1 || d > 9 || d != math.Trunc(d) || m.has(int(d)) {
+				panic(errors.New("invalid Sudoku board"))
+			}
+			m |= digitMask(int(d))
+		}
+		if m == 0 {
+			panic(errors.New("invalid Sudoku board"))
+		}
+	}
+
+	return newBoard(l, func(x, y int) *Cell {
+		return NewCellFromIntSlice(x, y, candidates[y*9+x])
+	})
+}
 
-	var row []int
+func newBoard(l *log.Logger, cell func(x, y int) *Cell) *Board {
 	b := &Board{
-		log: l,
-		strategies: []*Strategy{
-			&Strategy{"nothing", NothingStrategy},
-			&Strategy{"naked singles", SolveStripNakedSingles},
-			&Strategy{"hidden singles", SolveHiddenSingles},
-			&Strategy{"naked pairs", SolveNakedPairs},
-			&Strategy{"hidden pairs", SolveHiddenPairs},
-			&Strategy{"naked triples", SolveNakedTriples},
-			&Strategy{"hidden triples", SolveHiddenTriples},
-			&Strategy{"naked quads", SolveNakedQuads},
-			&Strategy{"hidden quads", SolveHiddenQuads},
-		},
-	}
-
-	for len(c) != 0 {
-		row, c = c[:9], c[9:]
+		log:        l,
+		strategies: append([]Strategy{nothing}, builtinStrategies()...),
+	}
+
+	for y := 0; y < 9; y++ {
 		var r []*Cell
-		for i, v := range row {
-			cell := NewCellFromInt(i, len(b.c), v)
-			r = append(r, cell)
-			b.fc = append(b.fc, cell)
+		for x := 0; x < 9; x++ {
+			c := cell(x, y)
+			r = append(r, c)
+			b.fc = append(b.fc, c)
 		}
 		b.c = append(b.c, r)
 	}
+	for u, cells := range unitCells {
+		b.units[u] = make([]*Cell, 0, 9)
+		for _, i := range cells {
+			b.units[u] = append(b.units[u], b.fc[i])
+		}
+	}
+	for i, cells := range peerCells {
+		b.peers[i] = make([]*Cell, 0, 20)
+		for _, j := range cells {
+			b.peers[i] = append(b.peers[i], b.fc[j])
+		}
+	}
+	b.indexPlaces()
+	b.puzzle = b.codeStr()
 
 	return b
 }
 
+// logf logs to the board logger, logging is disabled without a logger
+func (b *Board) logf(format string, v ...interface{}) {
+	if b.log != nil {
+		b.log.Printf(format, v...)
+	}
+}
+
 func (b *Board) numSolved() int {
 	var i int
 	for _, c := range b.fc {
@@ -77,50 +119,158 @@ func (b *Board) numSolved() int {
 	return i
 }
 
-func (b *Board) solve(maxDifficulty, exclude int) string {
-	b.log.Print(b.terseString())
-	b.log.Printf("Solving: %s", b.codeStr())
+func (b *Board) solve(maxDifficulty int, sel Selection) (string, []Step) {
+	hardest, steps, _ := b.solveContext(context.Background(), SolveOptions{Strategies: sel}, maxDifficulty)
+
+	return hardest, steps
+}
+
+// solveContext applies strategies until the board is solved, no strategy
+// applies or a limit of o is hit. It returns the hardest strategy used,
+// the applied steps and the reason solving stopped. Only the strategies
+// selected by o up to maxDifficulty are used
+func (b *Board) solveContext(ctx context.Context, o SolveOptions, maxDifficulty int) (string, []Step, StopReason) {
+	if b.log != nil {
+		b.log.Print(b.terseString())
+	}
+	b.logf("Solving: %s", b.codeStr())
 
 	numSolved := b.numSolved()
-	difficulty := 0
-	lastDifficulty := -1
+	var start Snapshot
+	if o.Compact {
+		start = b.Snapshot()
+	}
+	hardest := 0
+	steps := []Step{}
+	var reason StopReason
+	// propagation makes naked singles steps, it's off without the strategy.
+	// Compaction refinds steps by their strategy, which never finds them
+	var queue []int
+	nakedSingles := b.strategyIndex(nakedSinglesName)
+	propagate := o.Propagate && !o.Simplest && !o.Compact && nakedSingles > 0 && nakedSingles <= maxDifficulty && o.Strategies.allows(nakedSinglesName)
+	if propagate {
+		queue = b.solvedCells()
+	}
+
+	for reason == "" && !b.isSolved() {
+		if err := ctx.Err(); err != nil {
+			reason = contextStopReason(err)
+			break
+		}
+		limit := 0
+		if o.MaxSteps > 0 {
+			limit = o.MaxSteps - len(steps)
+			if limit <= 0 {
+				reason = StopMaxSteps
+				break
+			}
+		}
 
-	for lastDifficulty != 0 {
-		lastDifficulty = b.solveStrategies(maxDifficulty, exclude)
-		difficulty = int(math.Max(float64(difficulty), float64(lastDifficulty)))
+		var lastDifficulty int
+		var s []Step
+		if len(queue) > 0 {
+			lastDifficulty, s = nakedSingles, b.propagate(&queue, limit)
+		}
+		if len(s) == 0 {
+			lastDifficulty, s = b.solveStrategies(maxDifficulty, o, limit)
+			if propagate {
+				queue = appendPlacements(queue, s)
+			}
+		}
+		if lastDifficulty == 0 {
+			break
+		}
+		hardest = b.rateSteps(s, hardest)
+		steps = append(steps, s...)
+		if !b.emit(s) {
+			reason = StopCanceled
+		}
 	}
+	if o.Compact && reason == "" && b.isSolved() {
+		n := len(steps)
+		steps = b.compact(ctx, start, steps)
+		b.logf("Compacted the path from %d to %d steps:", n, len(steps))
+		for _, s := range steps {
+			b.logf(" * %s", s)
+		}
+		b.rating = start.rating
+		hardest = b.rateSteps(steps, 0)
+	}
+	b.rating.Solved = b.isSolved()
 
-	if b.isSolved() {
-		b.log.Printf("Completely solved! (solved %d cells)", b.numSolved()-numSolved)
-	} else {
-		b.log.Printf("...Cannot solve further (solved %d cells)", b.numSolved()-numSolved)
+	if reason == "" && !b.isSolved() && o.BruteForce {
+		var s []Step
+		s, reason = b.bruteForce(ctx, o.MaxNodes)
+		steps = append(steps, s...)
+		b.emit(s)
 	}
-	b.log.Printf("Most advanced strategy used: %s", b.strategies[difficulty].name)
-	b.log.Printf("Solved: %s", b.codeStr())
+	if reason == "" {
+		reason = StopStuck
+		if b.isSolved() {
+			reason = StopSolved
+		}
+	}
+
 	if b.isSolved() {
-		b.log.Print(b.terseString())
+		b.logf("Completely solved! (solved %d cells)", b.numSolved()-numSolved)
 	} else {
-		b.log.Print(b.verboseString())
+		b.logf("...Cannot solve further (solved %d cells, %s)", b.numSolved()-numSolved, reason)
+	}
+	b.logf("Most advanced strategy used: %s", b.strategies[hardest].Name())
+	b.logf("Rating: %.1f (%s), total effort %.1f", b.rating.Max, b.rating.Level(), b.rating.Total)
+	b.logf("Solved: %s", b.codeStr())
+	if b.log != nil {
+		if b.isSolved() {
+			b.log.Print(b.terseString())
+		} else {
+			b.log.Print(b.verboseString())
+		}
+	}
+
+	return b.strategies[hardest].Name(), steps, reason
+}
+
+// rateSteps adds the rated steps to the rating. It returns the index of
+// the strategy of the highest difficulty used by them or by hardest,
+// strategies can be registered in any order
+func (b *Board) rateSteps(steps []Step, hardest int) int {
+	for _, s := range steps {
+		if !rated(s) {
+			continue
+		}
+		i := b.strategyIndex(s.Strategy)
+		b.rating.add(b.strategies[i])
+		if b.strategies[i].Difficulty() > b.strategies[hardest].Difficulty() {
+			hardest = i
+		}
+	}
+
+	return hardest
+}
+
+// emit passes applied steps to onStep, it returns false to stop solving
+func (b *Board) emit(steps []Step) bool {
+	if b.onStep == nil {
+		return true
+	}
+	for _, s := range steps {
+		if !b.onStep(s) {
+			return false
+		}
 	}
 
-	return b.strategies[difficulty].name
+	return true
 }
 
 func (b *Board) verify() bool {
 	verified := true
-	for _, i := range MakeRange(9) {
-		verified = verified && b.verifyRow(int(i))
-		verified = verified && b.verifyCol(int(i))
-		verified = verified && b.verifyBlock(int(i))
+	for i := 0; i < 9; i++ {
+		verified = verified && b.verifyRow(i)
+		verified = verified && b.verifyCol(i)
+		verified = verified && b.verifyBlock(i)
 	}
-	c := combin.Cartesian(nil, [][]float64{MakeRange(9), MakeRange(9)})
-	rows, _ := c.Dims()
-	for i := 0; i < rows; i++ {
-		coords := c.RawRowView(i)
-		y, x := int(coords[0]), int(coords[1])
-		c := b.cell(x, y)
-		verified = verified &&
-			(len(c.candidates) >= 1 && len(c.candidates) <= 9 && Subset(c.candidates, MakeRange(1, 10)))
+	for _, c := range b.fc {
+		verified = verified && c.candidates != 0 && c.candidates&^allDigits == 0
 	}
 
 	if !verified {
@@ -131,59 +281,37 @@ func (b *Board) verify() bool {
 }
 
 func (b *Board) unit(t string, i int) []*Cell {
-	units := map[string]func(int) []*Cell{
-		"row":    b.row,
-		"column": b.col,
-		"block":  b.block,
-	}
-
-	return units[t](i)
+	return b.units[unitOffset(t)+i]
 }
 
 func (b *Board) unitName(t string, i int) string {
-	unitNames := map[string]string{
-		"row":    rows,
-		"column": cols,
-		"block":  blocks,
+	switch t {
+	case typeRow:
+		return rows[i : i+1]
+	case typeCol:
+		return cols[i : i+1]
 	}
 
-	return string(unitNames[t][i])
+	return blocks[i : i+1]
 }
 
 func (b *Board) cell(x, y int) *Cell {
 	return b.c[y][x]
 }
 
-func (b *Board) seenFrom(x, y int) []*Cell {
-	r := map[*Cell]bool{}
-
-	for _, v := range b.rowWithout(x, y) {
-		if _, ok := r[v]; ok {
-			continue
-		}
-		r[v] = true
-	}
-
-	for _, v := range b.colWithout(x, y) {
-		if _, ok := r[v]; ok {
-			continue
-		}
-		r[v] = true
-	}
-
-	for _, v := range b.blockWithout(x, y) {
-		if _, ok := r[v]; ok {
-			continue
-		}
-		r[v] = true
-	}
+// Candidates returns the candidates of the cell at p, a single digit for a solved cell
+func (b *Board) Candidates(p Position) []int {
+	return b.cell(p.X, p.Y).candidates.digits()
+}
 
-	v := []*Cell{}
-	for i := range r {
-		v = append(v, i)
-	}
+// IsGiven reports whether the cell at p was solved when the board was created
+func (b *Board) IsGiven(p Position) bool {
+	return b.puzzle[p.Y*9+p.X] != '.'
+}
 
-	return v
+// seenFrom returns the peers of the cell, the cells sharing a unit with it
+func (b *Board) seenFrom(x, y int) []*Cell {
+	return b.peers[y*9+x]
 }
 
 // func (b *Board) unitWithout(t string, i int, without []*Cell) []*Cell {
@@ -195,121 +323,96 @@ func (b *Board) seenFrom(x, y int) []*Cell {
 // }
 
 func (b *Board) verifyRow(i int) bool {
-	ex := map[int]bool{}
-	u := []float64{}
-	for _, c := range b.row(i) {
-		if ex[c.value()] != true {
-			ex[c.value()] = true
-			u = append(u, float64(c.value()))
-		}
-	}
-	return CompareFloat64Slices(u, MakeRange(1, 10))
+	return verifyUnit(b.row(i))
 }
 
 func (b *Board) verifyCol(i int) bool {
-	ex := map[int]bool{}
-	u := []float64{}
-	for _, c := range b.col(i) {
-		if ex[c.value()] != true {
-			ex[c.value()] = true
-			u = append(u, float64(c.value()))
-		}
-	}
-	return CompareFloat64Slices(u, MakeRange(1, 10))
+	return verifyUnit(b.col(i))
 }
 
 func (b *Board) verifyBlock(i int) bool {
-	ex := map[int]bool{}
-	u := []float64{}
-	for _, c := range b.block(i) {
-		if ex[c.value()] != true {
-			ex[c.value()] = true
-			u = append(u, float64(c.value()))
+	return verifyUnit(b.block(i))
+}
+
+// verifyUnit checks all cells of the unit are solved with distinct digits
+func verifyUnit(cells []*Cell) bool {
+	var u mask
+	for _, c := range cells {
+		if !c.isSolved() {
+			return false
 		}
+		u |= c.candidates
 	}
-	return CompareFloat64Slices(u, MakeRange(1, 10))
+
+	return u == allDigits
 }
 
-func (b *Board) solveStrategies(maxDifficulty, exclude int) int {
+// solveStrategies applies the deductions of the first applicable strategy,
+// at most limit of them unless limit is 0. With o.Simplest it applies
+// the simplest deduction of all strategies instead
+func (b *Board) solveStrategies(maxDifficulty int, o SolveOptions, limit int) (int, []Step) {
+	find := b.findStrategies
+	if o.Simplest {
+		find = b.findSimplest
+	}
+	i, found := find(maxDifficulty, o.Strategies)
+	if i == 0 {
+		return 0, nil
+	}
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+
+	steps := b.apply(found)
+	if len(steps) == 0 {
+		return 0, nil
+	}
+	for _, s := range steps {
+		b.logf(" * %s", s)
+	}
+
+	return i, steps
+}
+
+// findStrategies runs strategies from the easiest one and returns the index
+// and deductions of the first applicable strategy selected by sel.
+// The board isn't changed
+func (b *Board) findStrategies(maxDifficulty int, sel Selection) (int, []Step) {
 	if b.isSolved() {
-		return 0
+		return 0, nil
 	}
 	for i := 0; i < len(b.strategies); i++ {
-		if i == 0 || i > maxDifficulty {
+		if i == 0 || i > maxDifficulty || !sel.allows(b.strategies[i].Name()) {
 			continue
 		}
 
-		b.log.Printf("Try %s", b.strategies[i].name)
-		changed := b.strategies[i].f(b)
+		b.logf("Try %s", b.strategies[i].Name())
+		steps := b.strategies[i].Apply(b)
 
-		if !changed {
-			b.log.Printf("...No %s found", b.strategies[i].name)
+		if len(steps) == 0 {
+			b.logf("...No %s found", b.strategies[i].Name())
+			continue
 		}
-		if changed {
-			return i
+		for j := range steps {
+			steps[j].Strategy = b.strategies[i].Name()
 		}
+
+		return i, steps
 	}
 
-	return 0
+	return 0, nil
 }
 
 func (b *Board) row(y int) []*Cell {
-	return b.c[y]
-}
-func (b *Board) rowWithout(x, y int) []*Cell {
-	r := []*Cell{}
-	r = append(r, b.c[y][:x]...)
-	r = append(r, b.c[y][x+1:]...)
-
-	return r
+	return b.units[y]
 }
 
 func (b *Board) col(x int) []*Cell {
-	r := []*Cell{}
-	for _, v := range b.c {
-		r = append(r, v[x])
-	}
-
-	return r
-}
-
-func (b *Board) colWithout(x, y int) []*Cell {
-	r := []*Cell{}
-	for i, v := range b.c {
-		if i != y {
-			r = append(r, v[x])
-		}
-	}
-
-	return r
+	return b.units[9+x]
 }
 
 func (b *Board) block(i int) []*Cell {
-	y, x := i/3, i%3
-
-	r := [9]*Cell{}
-	for i, v := range MakeRange(3) {
-		for j, c := range b.c[3*y+int(v)][3*x : 3*x+3] {
-			r[i*3+j] = c
-		}
-	}
-
-	return r[0:len(r)]
-}
-
-func (b *Board) blockWithout(x, y int) []*Cell {
-	bx3, by3 := x/3*3, y/3*3
-
-	r := []*Cell{}
-	for i := range MakeRange(3) {
-		for j := range MakeRange(3) {
-			if by3+i != y || bx3+j != x {
-				r = append(r, b.c[by3+i][bx3+j])
-			}
-		}
-	}
-
-	return r
+	return b.units[18+i]
 }
 
 func (b *Board) isSolved() bool {
@@ -367,26 +470,35 @@ J | %s %s %s | %s %s %s | %s %s %s |
 }
 
 func (b *Board) verboseString() string {
-	r := []interface{}{}
-
-	for _, c := range b.fc {
+	// each cell is drawn as 3 lines of 3 characters
+	chars := make([][]string, 81)
+	for i, c := range b.fc {
 		if c.isSolved() {
 			for _, ch := range fmt.Sprintf("   (%d)   ", c.value()) {
-				r = append(r, fmt.Sprintf("%c", ch))
+				chars[i] = append(chars[i], string(ch))
 			}
 			continue
 		}
-		for _, v := range MakeRange(1, 10) {
-			if c.isCandidate(v) {
-				r = append(r, strconv.Itoa(int(v)))
+		for d := 1; d <= 9; d++ {
+			if c.isCandidate(d) {
+				chars[i] = append(chars[i], strconv.Itoa(d))
 			} else {
-				r = append(r, ".")
+				chars[i] = append(chars[i], ".")
 			}
 		}
+	}
 
+	r := []interface{}{}
+	for y := 0; y < 9; y++ {
+		for line := 0; line < 3; line++ {
+			for x := 0; x < 9; x++ {
+				for _, ch := range chars[y*9+x][line*3 : line*3+3] {
+					r = append(r, ch)
+				}
+			}
+		}
 	}
 
-	row := Transpose(funk.Chunk(funk.Chunk(funk.Chunk(r, 3), 3), 9).([][][][]interface{})...)
 	template := `
      1   2   3     4   5   6     7   8   9
   +-------------+-------------+-------------+
@@ -427,5 +539,5 @@ J | %s%s%s %s%s%s %s%s%s | %s%s%s %s%s%s %s%s%s | %s%s%s %s%s%s %s%s%s |
   | %s%s%s %s%s%s %s%s%s | %s%s%s %s%s%s %s%s%s | %s%s%s %s%s%s %s%s%s |
   +-------------+-------------+-------------+
 `
-	return fmt.Sprintf(template, funk.FlattenDeep(row).([]interface{})...)
+	return fmt.Sprintf(template, r...)
 }
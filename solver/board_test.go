@@ -8,12 +8,19 @@ import (
 	"testing"
 )
 
+const (
+	// mediumPuzzle is solved by the strategies
+	mediumPuzzle = "000000001000000023004005000000002000010000400360070000000610000005000800007030000"
+	// hardPuzzle gets stuck without brute force
+	hardPuzzle = "800000000003600000070090200050007000000045700000100030001000068008500010090000400"
+)
+
 func TesеNakedSinglesSolving(t *testing.T) {
 	log := log.New(ioutil.Discard, "", 0)
 	b := NewBoard(log, "273964851469158723185273469821346597546792318397815246718529634632481975954637180")
 
 	solution := "273964851469158723185273469821346597546792318397815246718529634632481975954637182"
-	b.solve(1, 0)
+	b.solve(1, Selection{})
 
 	if solution != b.codeStr() {
 		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
@@ -25,7 +32,7 @@ func TestHiddenSinglesSolving(t *testing.T) {
 	b := NewBoard(log, "000000001000000020000003000000040500006000300007810000010020004030000070950000000")
 
 	solution := "273964851469158723185273469821346597546792318397815246718529634632481975954637182"
-	b.solve(2, 0)
+	b.solve(2, Selection{})
 
 	if solution != b.codeStr() {
 		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
@@ -37,7 +44,7 @@ func TestNakedPairsSolving(t *testing.T) {
 	b := NewBoard(log, "000000000000000012003045000000000400000600000060100070000260080405000009700000000")
 
 	solution := "678921345954736812213845697891573426347692158562184973139267584425318769786459231"
-	b.solve(3, 0)
+	b.solve(3, Selection{})
 
 	if solution != b.codeStr() {
 		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
@@ -49,7 +56,7 @@ func TestNakedTriplesSolving(t *testing.T) {
 	b := NewBoard(log, "000000001000000023004005000000010000006027000089000500000400900050900000100000000")
 
 	solution := "938742651571698423624135789745819236316527894289364517863451972452973168197286345"
-	b.solve(5, 0)
+	b.solve(5, Selection{})
 
 	if solution != b.codeStr() {
 		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
@@ -61,7 +68,7 @@ func TestNakedQuadsSolving(t *testing.T) {
 	b := NewBoard(log, "000000001000000002000034000000000050001600000370000040000800000006102000050000930")
 
 	solution := "425768391783915462619234785264389157591647823378521649947853216836192574152476938"
-	b.solve(7, 0)
+	b.solve(7, Selection{})
 
 	if solution != b.codeStr() {
 		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
@@ -70,10 +77,10 @@ func TestNakedQuadsSolving(t *testing.T) {
 
 func TestHiddenPairsSolving(t *testing.T) {
 	log := log.New(ioutil.Discard, "", 0)
-	b := NewBoard(log, "000000001000000023004005000000002000010000400360070000000610000005000800007030000")
+	b := NewBoard(log, mediumPuzzle)
 
 	solution := "276389541581746923934125678458962317712853469369471285893614752145297836627538194"
-	b.solve(4, 0)
+	b.solve(4, Selection{})
 
 	if solution != b.codeStr() {
 		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
@@ -85,7 +92,7 @@ func TestHiddenTriplesSolving(t *testing.T) {
 	b := NewBoard(log, "000000000000000012000034000000000300005006400070100008000200070304000500600000000")
 
 	solution := "758612943439587612162934785246879351815326497973145268591263874384791526627458139"
-	b.solve(6, 0)
+	b.solve(6, Selection{})
 
 	if solution != b.codeStr() {
 		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
@@ -97,7 +104,7 @@ func TestHiddenQuadsSolving(t *testing.T) {
 	b := NewBoard(log, "000000001000000023004005000000006000070000000120030000000210070006000400500080000")
 
 	solution := "857362941961748523234195867493576218675821394128439756389214675716953482542687139"
-	b.solve(8, 0)
+	b.solve(8, Selection{})
 
 	if solution != b.codeStr() {
 		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
@@ -188,3 +195,14 @@ J | (9) (5) 456 | 456 456 456 | 456 456 456 |
 		t.Errorf("Board terse string is not correct: %s, expected: %s", b.verboseString(), solution)
 	}
 }
+
+func TestSolveWithoutLogger(t *testing.T) {
+	b := NewBoard(nil, "000000001000000020000003000000040500006000300007810000010020004030000070950000000")
+
+	solution := "273964851469158723185273469821346597546792318397815246718529634632481975954637182"
+	b.solve(2, Selection{})
+
+	if solution != b.codeStr() {
+		t.Errorf("Board: %s, is not solved correctly: %s", b.codeStr(), solution)
+	}
+}
@@ -0,0 +1,223 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"strings"
+)
+
+// ctxCheckNodes is the number of nodes between checks of the search context
+const ctxCheckNodes = 1024
+
+// search is a backtracking search over a grid of digits, 0 is an empty cell
+type search struct {
+	grid               [81]int
+	rows, cols, blocks [9]uint16
+	// candidates are the allowed digits of each cell
+	candidates   [81]uint16
+	limit, count int
+	solution     [81]int
+	rng          *rand.Rand
+	// ctx and maxNodes stop the search, stop is the reason it stopped
+	ctx             context.Context
+	nodes, maxNodes int
+	stop            StopReason
+}
+
+// newSearch returns false if the givens contradict each other
+func newSearch(givens []int) (*search, bool) {
+	s := &search{}
+	for i := range s.candidates {
+		s.candidates[i] = 0x1ff
+	}
+	for i, v := range givens {
+		if v == 0 {
+			continue
+		}
+		if s.allowed(i)&(1<<uint(v-1)) == 0 {
+			return nil, false
+		}
+		s.set(i, v)
+	}
+
+	return s, true
+}
+
+func (s *search) allowed(i int) uint16 {
+	y, x := i/9, i%9
+	return s.candidates[i] &^ (s.rows[y] | s.cols[x] | s.blocks[y/3*3+x/3])
+}
+
+func (s *search) set(i, v int) {
+	y, x, m := i/9, i%9, uint16(1)<<uint(v-1)
+	s.grid[i] = v
+	s.rows[y] |= m
+	s.cols[x] |= m
+	s.blocks[y/3*3+x/3] |= m
+}
+
+func (s *search) unset(i int) {
+	y, x, m := i/9, i%9, ^(uint16(1) << uint(s.grid[i]-1))
+	s.grid[i] = 0
+	s.rows[y] &= m
+	s.cols[x] &= m
+	s.blocks[y/3*3+x/3] &= m
+}
+
+// run counts solutions up to the limit, the first one is kept
+func (s *search) run() {
+	s.nodes++
+	if s.maxNodes > 0 && s.nodes > s.maxNodes {
+		s.stop = StopMaxNodes
+		return
+	}
+	if s.ctx != nil && s.nodes%ctxCheckNodes == 0 {
+		if err := s.ctx.Err(); err != nil {
+			s.stop = contextStopReason(err)
+			return
+		}
+	}
+
+	// the empty cell with the fewest candidates
+	best, bestAllowed, bestCount := -1, uint16(0), 10
+	for i, v := range s.grid {
+		if v != 0 {
+			continue
+		}
+		a := s.allowed(i)
+		if n := bits.OnesCount16(a); n < bestCount {
+			best, bestAllowed, bestCount = i, a, n
+		}
+	}
+
+	if best == -1 {
+		if s.count == 0 {
+			s.solution = s.grid
+		}
+		s.count++
+		return
+	}
+
+	ds := []int{}
+	for d := 1; d <= 9; d++ {
+		if bestAllowed&(1<<uint(d-1)) != 0 {
+			ds = append(ds, d)
+		}
+	}
+	if s.rng != nil {
+		s.rng.Shuffle(len(ds), func(i, j int) { ds[i], ds[j] = ds[j], ds[i] })
+	}
+
+	for _, d := range ds {
+		s.set(best, d)
+		s.run()
+		s.unset(best)
+		if s.count >= s.limit || s.stop != "" {
+			return
+		}
+	}
+}
+
+// CountSolutions returns the number of solutions of the givens,
+// 81 digits where 0 is an empty cell. Counting stops at limit
+func CountSolutions(givens []int, limit int) int {
+	n, _ := CountSolutionsContext(context.Background(), givens, limit)
+
+	return n
+}
+
+// CountSolutionsContext counts solutions like CountSolutions, but stops
+// with the error of ctx when it's done
+func CountSolutionsContext(ctx context.Context, givens []int, limit int) (int, error) {
+	s, ok := newSearch(givens)
+	if !ok {
+		return 0, nil
+	}
+	s.limit, s.ctx = limit, ctx
+	s.run()
+	if s.stop != "" {
+		return 0, ctx.Err()
+	}
+
+	return s.count, nil
+}
+
+// SolveBruteForce returns a solution of the givens found by backtracking
+func SolveBruteForce(givens []int) ([]int, bool) {
+	r, ok, _ := SolveBruteForceContext(context.Background(), givens)
+
+	return r, ok
+}
+
+// SolveBruteForceContext finds a solution like SolveBruteForce, but stops
+// with the error of ctx when it's done
+func SolveBruteForceContext(ctx context.Context, givens []int) ([]int, bool, error) {
+	s, ok := newSearch(givens)
+	if !ok {
+		return nil, false, nil
+	}
+	s.limit, s.ctx = 1, ctx
+	s.run()
+	if s.stop != "" {
+		return nil, false, ctx.Err()
+	}
+	if s.count == 0 {
+		return nil, false, nil
+	}
+
+	return s.solution[:], true, nil
+}
+
+// Generate creates a random puzzle with a unique solution. Givens are
+// removed in random order while the solution stays unique, so the puzzle
+// is minimal. The puzzle is returned as 81 characters, '.' is an empty cell
+func Generate(rng *rand.Rand) string {
+	s, _ := newSearch(make([]int, 81))
+	s.limit, s.rng = 1, rng
+	s.run()
+	grid := s.solution[:]
+
+	for _, i := range rng.Perm(81) {
+		v := grid[i]
+		grid[i] = 0
+		if CountSolutions(grid, 2) != 1 {
+			grid[i] = v
+		}
+	}
+
+	r := strings.Builder{}
+	for _, v := range grid {
+		if v == 0 {
+			r.WriteByte('.')
+		} else {
+			r.WriteByte(byte('0' + v))
+		}
+	}
+
+	return r.String()
+}
+
+// ErrUnknownLevel is returned for a level other than the Level constants
+var ErrUnknownLevel = errors.New("unknown level")
+
+// GenerateLevel generates puzzles until one of the level is solved by the
+// strategies and returns it with its solving result. An empty level takes
+// the first puzzle. It stops with the error of ctx when ctx is done
+func GenerateLevel(ctx context.Context, rng *rand.Rand, level string) (string, Result, error) {
+	if !isLevel(level) {
+		return "", Result{}, fmt.Errorf("%w %q", ErrUnknownLevel, level)
+	}
+
+	for ctx.Err() == nil {
+		p := Generate(rng)
+		res := NewBoard(nil, p).SolveContext(ctx, SolveOptions{})
+		if level == "" || res.Solved && res.Rating.Level() == level {
+			return p, res, nil
+		}
+	}
+
+	return "", Result{}, ctx.Err()
+}
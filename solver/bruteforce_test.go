@@ -0,0 +1,90 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestSolveBruteForce(t *testing.T) {
+	givens, _ := ParseGivens("000000001000000023004005000000006000070000000120030000000210070006000400500080000")
+
+	r, ok := SolveBruteForce(givens)
+	solution := "857362941961748523234195867493576218675821394128439756389214675716953482542687139"
+	for i := range solution {
+		if !ok || r[i] != int(solution[i]-'0') {
+			t.Fatalf("brute force solution is: %v, expected: %s", r, solution)
+		}
+	}
+}
+
+func TestCountSolutions(t *testing.T) {
+	givens, _ := ParseGivens("000000001000000023004005000000006000070000000120030000000210070006000400500080000")
+	if n := CountSolutions(givens, 2); n != 1 {
+		t.Errorf("puzzle has %d solutions, expected 1", n)
+	}
+
+	givens[80] = 0
+	givens[79] = 0
+	givens[8] = 0
+	if n := CountSolutions(givens, 2); n != 2 {
+		t.Errorf("puzzle has %d solutions, expected at least 2", n)
+	}
+
+	givens[0], givens[1] = 5, 5
+	if n := CountSolutions(givens, 2); n != 0 {
+		t.Errorf("contradicting puzzle has %d solutions, expected 0", n)
+	}
+}
+
+func TestCountSolutionsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// an empty grid has too many solutions to count without the context
+	if n, err := CountSolutionsContext(ctx, make([]int, 81), 1<<30); err != context.Canceled || n != 0 {
+		t.Errorf("solutions: %d, error: %v, expected the context error", n, err)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	p := Generate(rand.New(rand.NewSource(1)))
+
+	givens, err := ParseGivens(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := CountSolutions(givens, 2); n != 1 {
+		t.Errorf("generated puzzle %s has %d solutions", p, n)
+	}
+
+	// removing any given makes the solution ambiguous
+	for i, v := range givens {
+		if v == 0 {
+			continue
+		}
+		givens[i] = 0
+		if CountSolutions(givens, 2) == 1 {
+			t.Errorf("generated puzzle %s is not minimal, %s can be removed", p, Position{i % 9, i / 9}.Name())
+		}
+		givens[i] = v
+	}
+}
+
+func TestGenerateLevel(t *testing.T) {
+	p, res, err := GenerateLevel(context.Background(), rand.New(rand.NewSource(1)), LevelMedium)
+	if err != nil || !res.Solved || res.Rating.Level() != LevelMedium || res.Puzzle != NewBoard(nil, p).puzzle {
+		t.Errorf("puzzle: %s, result: %+v, error: %v", p, res, err)
+	}
+
+	if _, _, err := GenerateLevel(context.Background(), rand.New(rand.NewSource(1)), "Impossible"); !errors.Is(err, ErrUnknownLevel) || err.Error() != `unknown level "Impossible"` {
+		t.Errorf("unknown level error is: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := GenerateLevel(ctx, rand.New(rand.NewSource(1)), LevelExpert); err != context.Canceled {
+		t.Errorf("error is: %v, expected the context error", err)
+	}
+}
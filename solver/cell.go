@@ -2,8 +2,8 @@ package solver
 
 import (
 	"fmt"
+	"math/bits"
 	"strconv"
-	"unicode/utf8"
 )
 
 var rows = "ABCDEFGHJ"
@@ -16,31 +16,75 @@ const (
 	typeBlock = "block"
 )
 
+// mask is a set of digits, bit d-1 is set if digit d is in the set
+type mask uint16
+
+// allDigits is the mask of digits 1 to 9
+const allDigits mask = 0x1ff
+
+// digitMask returns the mask of a single digit
+func digitMask(d int) mask {
+	return 1 << uint(d-1)
+}
+
+// maskOf returns the mask of digits
+func maskOf(ds ...int) mask {
+	var m mask
+	for _, d := range ds {
+		m |= digitMask(d)
+	}
+
+	return m
+}
+
+func (m mask) has(d int) bool {
+	return m&digitMask(d) != 0
+}
+
+func (m mask) count() int {
+	return bits.OnesCount16(uint16(m))
+}
+
+// digits returns the digits of the set in ascending order
+func (m mask) digits() []int {
+	r := make([]int, 0, m.count())
+	for v := uint16(m); v != 0; v &= v - 1 {
+		r = append(r, bits.TrailingZeros16(v)+1)
+	}
+
+	return r
+}
+
 // Cell is sudoku cell representation
 type Cell struct {
 	x          int
 	y          int
 	b          int
-	candidates []float64
+	candidates mask
 }
 
 // NewCellFromIntSlice creates new Cell object with candidates from int slice
 // x,y: coorinats on the board
 // c: cell candidates for the answer.
 func NewCellFromIntSlice(x, y int, c []float64) *Cell {
-	return &Cell{x, y, y/3*3 + x/3, c}
+	var m mask
+	for _, d := range c {
+		m |= digitMask(int(d))
+	}
+
+	return &Cell{x, y, y/3*3 + x/3, m}
 }
 
 // NewCellFromInt creates new Cell object with single candidate
 // x,y: coordinats on the board
 // c: cell answer
 func NewCellFromInt(x, y, c int) *Cell {
-	r := []float64{float64(c)}
+	m := digitMask(c)
 	if c == 0 {
-		r = MakeRange(1, 10)
+		m = allDigits
 	}
 
-	return &Cell{x, y, y/3*3 + x/3, r}
+	return &Cell{x, y, y/3*3 + x/3, m}
 }
 
 // func (c *Cell) rowName() (r rune) {
@@ -68,22 +112,20 @@ func NewCellFromInt(x, y, c int) *Cell {
 // }
 
 func (c *Cell) cellName() string {
-	row, _ := utf8.DecodeRuneInString(rows[c.y:])
-	col, _ := utf8.DecodeRuneInString(cols[c.x:])
-	return string(row) + string(col)
+	return Position{c.x, c.y}.Name()
 }
 
 func (c *Cell) isSolved() bool {
-	return len(c.candidates) == 1
+	return c.candidates.count() == 1
 }
 
 // func (c *Cell) isBiValue() bool {
-// 	return len(c.candidates) == 2
+// 	return c.candidates.count() == 2
 // }
 
 func (c *Cell) value() int {
 	if c.isSolved() {
-		return int(c.candidates[0])
+		return bits.TrailingZeros16(uint16(c.candidates)) + 1
 	}
 
 	return 0
@@ -94,38 +136,27 @@ func (c *Cell) stringValue() string {
 		return strconv.Itoa(c.value())
 	}
 
-	return fmt.Sprintf("%v", c.candidates)
+	return fmt.Sprintf("%v", c.candidates.digits())
 }
 
-func (c *Cell) exclude(ds []float64) bool {
-	n := len(c.candidates)
+// exclude removes the digits of m from the candidates, it returns
+// true if any candidate was removed
+func (c *Cell) exclude(m mask) bool {
+	n := c.candidates
+	c.candidates &^= m
 
-	for _, e := range ds {
-		for i, v := range c.candidates {
-			if v == e {
-				c.candidates = append(c.candidates[:i], c.candidates[i+1:]...)
-			}
-		}
-	}
-
-	return len(c.candidates) < n
+	return c.candidates != n
 }
 
-func (c *Cell) includeOnly(ds []float64) bool {
-	n := len(c.candidates)
-	intersection, _ := Intersect(c.candidates, ds)
-
-	c.candidates = intersection.Interface().([]float64)
+// includeOnly keeps only the candidates in m, it returns
+// true if any candidate was removed
+func (c *Cell) includeOnly(m mask) bool {
+	n := c.candidates
+	c.candidates &= m
 
-	return len(c.candidates) < n
+	return c.candidates != n
 }
 
-func (c *Cell) isCandidate(can float64) bool {
-	for _, v := range c.candidates {
-		if can == v {
-			return true
-		}
-	}
-
-	return false
+func (c *Cell) isCandidate(d int) bool {
+	return c.candidates.has(d)
 }
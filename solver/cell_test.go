@@ -3,34 +3,31 @@ package solver
 import (
 	"reflect"
 	"testing"
-
-	"github.com/davecgh/go-spew/spew"
 )
 
 func TestNewCellConstructWithSingleInt(t *testing.T) {
 	c := NewCellFromInt(0, 0, 1)
 
-	ex := []float64{1}
-	if !reflect.DeepEqual(c.candidates, ex) {
-		t.Errorf("%v must be equal %v", c.candidates, ex)
+	ex := []int{1}
+	if !reflect.DeepEqual(c.candidates.digits(), ex) {
+		t.Errorf("%v must be equal %v", c.candidates.digits(), ex)
 	}
 }
 
 func TestNewCellConstructFromSliceOfInts(t *testing.T) {
 	c := NewCellFromIntSlice(0, 0, []float64{1, 2, 3})
 
-	ex := []float64{1, 2, 3}
-	if !reflect.DeepEqual(c.candidates, ex) {
-		t.Errorf("%v must be equal %v", c.candidates, ex)
+	ex := []int{1, 2, 3}
+	if !reflect.DeepEqual(c.candidates.digits(), ex) {
+		t.Errorf("%v must be equal %v", c.candidates.digits(), ex)
 	}
 }
 
 func TestNewCellConstructWithZero(t *testing.T) {
 	c := NewCellFromInt(0, 0, 0)
 
-	ex := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}
-	if !reflect.DeepEqual(c.candidates, ex) {
-		t.Errorf("%v must be equal %v", c.candidates, ex)
+	if c.candidates != allDigits {
+		t.Errorf("%v must be equal %v", c.candidates.digits(), allDigits.digits())
 	}
 }
 
@@ -149,17 +146,32 @@ func TestStringValue(t *testing.T) {
 func TestExclude(t *testing.T) {
 	c := NewCellFromIntSlice(0, 0, []float64{4, 6, 7, 8})
 
-	r := c.exclude([]float64{6, 7})
-	ex := []float64{4, 8}
-	if r != true || !reflect.DeepEqual(c.candidates, ex) {
-		t.Errorf("must be excluded: %s, result: %s", spew.Sprint(c.candidates), spew.Sprint(ex))
+	r := c.exclude(maskOf(6, 7))
+	ex := []int{4, 8}
+	if r != true || !reflect.DeepEqual(c.candidates.digits(), ex) {
+		t.Errorf("must be excluded: %v, result: %v", c.candidates.digits(), ex)
+	}
+
+	if c.exclude(maskOf(6, 7)) {
+		t.Errorf("excluding removed candidates can't change the cell")
 	}
 }
 
 func TestIncludeOnly(t *testing.T) {
 	c := NewCellFromIntSlice(0, 0, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
 
-	if c.includeOnly([]float64{9}) != true {
+	if c.includeOnly(maskOf(9)) != true || c.value() != 9 {
 		t.Errorf("must be included")
 	}
 }
+
+func TestMask(t *testing.T) {
+	m := maskOf(9, 1, 5)
+
+	if m.count() != 3 || !m.has(5) || m.has(2) || !reflect.DeepEqual(m.digits(), []int{1, 5, 9}) {
+		t.Errorf("mask of 1, 5, 9 is: %09b, digits: %v", m, m.digits())
+	}
+	if allDigits.count() != 9 || mask(0).count() != 0 || len(mask(0).digits()) != 0 {
+		t.Errorf("invalid mask of all digits or no digits")
+	}
+}
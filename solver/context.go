@@ -0,0 +1,120 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// StopReason tells why solving stopped
+type StopReason string
+
+const (
+	// StopSolved means the board is solved
+	StopSolved StopReason = "solved"
+	// StopStuck means no strategy applies to the board
+	StopStuck StopReason = "stuck"
+	// StopCanceled means the context was canceled or the step callback failed
+	StopCanceled StopReason = "canceled"
+	// StopDeadline means the deadline of the context was exceeded
+	StopDeadline StopReason = "deadline"
+	// StopMaxSteps means the step limit was hit
+	StopMaxSteps StopReason = "max_steps"
+	// StopMaxNodes means the node limit of the brute force search was hit
+	StopMaxNodes StopReason = "max_nodes"
+	// StopNoSolution means the brute force search proved the board has no solution
+	StopNoSolution StopReason = "no_solution"
+)
+
+// bruteForceStrategy names the brute force step, it isn't part of the rating
+const bruteForceStrategy = "brute force"
+
+// SolveOptions limit solving, zero values mean no limit
+type SolveOptions struct {
+	// MaxSteps limits the number of steps
+	MaxSteps int
+	// Propagate removes the digit of a solved cell from its peers as soon
+	// as the cell is solved, instead of rescanning the board for naked singles.
+	// Digits are still placed by the strategies in their order, so the
+	// rating is the same as without propagation
+	Propagate bool
+	// BruteForce finishes the board by a backtracking search
+	// when no strategy applies
+	BruteForce bool
+	// MaxNodes limits the nodes visited by the brute force search
+	MaxNodes int
+	// Strategies selects the strategies to use, all of them by default
+	Strategies Selection
+	// Simplest applies a single deduction at a time, the one of the lowest
+	// difficulty with the biggest effect among all strategies, instead of
+	// all deductions of the first applicable strategy. Propagate is ignored
+	Simplest bool
+	// Compact drops the steps the solution doesn't depend on from the result
+	// of a board solved by strategies. Steps are passed to a step callback
+	// before compaction. Propagate is ignored
+	Compact bool
+}
+
+// SolveContext solves the board using the strategies selected by o, but stops
+// when ctx is done or a limit of o is hit. The result holds the partially
+// solved board and the reason solving stopped. The rating covers only
+// the logical steps, not the brute force one
+func (b *Board) SolveContext(ctx context.Context, o SolveOptions) Result {
+	hardest, steps, reason := b.solveContext(ctx, o, len(b.strategies)-1)
+
+	r := b.result(hardest, steps)
+	r.StopReason = reason
+
+	return r
+}
+
+func contextStopReason(err error) StopReason {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return StopDeadline
+	}
+
+	return StopCanceled
+}
+
+// bruteForce solves the rest of the board by a backtracking search within
+// the candidates of its cells. It returns a single step eliminating all
+// candidates but the solution, or the reason the search failed
+func (b *Board) bruteForce(ctx context.Context, maxNodes int) ([]Step, StopReason) {
+	s := &search{limit: 1, ctx: ctx, maxNodes: maxNodes}
+	for i, c := range b.fc {
+		s.candidates[i] = uint16(c.candidates)
+	}
+	for i, c := range b.fc {
+		if c.isSolved() {
+			if s.allowed(i)&(1<<uint(c.value()-1)) == 0 {
+				return nil, StopNoSolution
+			}
+			s.set(i, c.value())
+		}
+	}
+
+	s.run()
+	if s.stop != "" {
+		return nil, s.stop
+	}
+	if s.count == 0 {
+		return nil, StopNoSolution
+	}
+	b.logf("Brute force: %d nodes", s.nodes)
+
+	step := Step{Strategy: bruteForceStrategy, Cells: []Position{}, Digits: []int{}}
+	for i, c := range b.fc {
+		for _, d := range c.candidates.digits() {
+			if d != s.solution[i] {
+				step.Eliminations = append(step.Eliminations, Candidate{Position{c.x, c.y}, d})
+			}
+		}
+	}
+	step.Description = fmt.Sprintf("Brute force: %d candidates eliminated by search", len(step.Eliminations))
+	steps := b.apply([]Step{step})
+	for _, s := range steps {
+		b.logf(" * %s", s)
+	}
+
+	return steps, StopSolved
+}
@@ -0,0 +1,56 @@
+package solver
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSolveContext(t *testing.T) {
+	r := NewBoard(nil, mediumPuzzle).SolveContext(context.Background(), SolveOptions{})
+	if !r.Solved || r.StopReason != StopSolved {
+		t.Errorf("solved: %v, stop reason: %s", r.Solved, r.StopReason)
+	}
+
+	r = NewBoard(nil, mediumPuzzle).SolveContext(context.Background(), SolveOptions{MaxSteps: 3})
+	if r.Solved || len(r.Steps) != 3 || r.StopReason != StopMaxSteps || r.Candidates == "" {
+		t.Errorf("solved: %v, steps: %d, stop reason: %s", r.Solved, len(r.Steps), r.StopReason)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r = NewBoard(nil, mediumPuzzle).SolveContext(ctx, SolveOptions{})
+	if r.Solved || len(r.Steps) != 0 || r.StopReason != StopCanceled {
+		t.Errorf("solved: %v, steps: %d, stop reason: %s", r.Solved, len(r.Steps), r.StopReason)
+	}
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	r = NewBoard(nil, mediumPuzzle).SolveContext(ctx, SolveOptions{})
+	if r.StopReason != StopDeadline {
+		t.Errorf("stop reason: %s, expected: %s", r.StopReason, StopDeadline)
+	}
+}
+
+func TestSolveContextBruteForce(t *testing.T) {
+	r := NewBoard(nil, hardPuzzle).SolveContext(context.Background(), SolveOptions{})
+	if r.Solved || r.StopReason != StopStuck {
+		t.Fatalf("solved: %v, stop reason: %s", r.Solved, r.StopReason)
+	}
+
+	r = NewBoard(nil, hardPuzzle).SolveContext(context.Background(), SolveOptions{BruteForce: true})
+	if !r.Solved || r.StopReason != StopSolved || r.Grid != "812753649943682175675491283154237896369845721287169534521974368438526917796318452" {
+		t.Errorf("solved: %v, stop reason: %s, grid: %s", r.Solved, r.StopReason, r.Grid)
+	}
+	if last := r.Steps[len(r.Steps)-1]; last.Strategy != bruteForceStrategy || len(last.Placements) == 0 {
+		t.Errorf("last step is: %+v, expected brute force", last)
+	}
+	if r.Rating.Solved {
+		t.Errorf("rating must cover only the logical steps: %+v", r.Rating)
+	}
+
+	r = NewBoard(nil, hardPuzzle).SolveContext(context.Background(), SolveOptions{BruteForce: true, MaxNodes: 5})
+	if r.Solved || r.StopReason != StopMaxNodes {
+		t.Errorf("solved: %v, stop reason: %s", r.Solved, r.StopReason)
+	}
+}
@@ -0,0 +1,20 @@
+package solver
+
+import "fmt"
+
+// FindAll returns every deduction of the named strategy in the current
+// candidates of the board, e.g. all naked pairs which eliminate candidates,
+// without applying them. The board isn't changed
+func FindAll(b *Board, strategy string) ([]Step, error) {
+	i := b.strategyIndex(strategy)
+	if i == 0 {
+		return nil, fmt.Errorf("unknown strategy %q", strategy)
+	}
+
+	steps := b.strategies[i].Apply(b)
+	for j := range steps {
+		steps[j].Strategy = b.strategies[i].Name()
+	}
+
+	return steps, nil
+}
@@ -0,0 +1,37 @@
+package solver
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFindAll(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+	b.SolveContext(context.Background(), SolveOptions{Strategies: Selection{Include: []string{"naked singles", "hidden singles"}}})
+	before := b.CandidateString()
+
+	pairs, err := FindAll(b, "hidden pairs")
+	if err != nil || len(pairs) == 0 {
+		t.Fatalf("hidden pairs: %v, error: %v", pairs, err)
+	}
+	for _, s := range pairs {
+		if s.Strategy != "hidden pairs" || len(s.Cells) != 2 || len(s.Eliminations) == 0 {
+			t.Errorf("not a hidden pair: %+v", s)
+		}
+	}
+	if b.CandidateString() != before {
+		t.Errorf("the board must not be changed:\n%s\nbefore:\n%s", b.CandidateString(), before)
+	}
+
+	_, hint := b.findStrategies(len(b.strategies)-1, Selection{Include: []string{"hidden pairs"}})
+	if len(hint) != len(pairs) {
+		t.Errorf("found %d hidden pairs, the solver finds %d", len(pairs), len(hint))
+	}
+
+	if singles, err := FindAll(b, "hidden singles"); err != nil || len(singles) != 0 {
+		t.Errorf("the board is stuck with singles, found: %v, error: %v", singles, err)
+	}
+	if _, err := FindAll(b, "x-wing"); err == nil || err.Error() != `unknown strategy "x-wing"` {
+		t.Errorf("unknown strategy error is: %v", err)
+	}
+}
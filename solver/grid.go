@@ -0,0 +1,60 @@
+package solver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CandidateString returns the candidate grid of the board: 81 candidate
+// strings, nine per line, separated by whitespace. A solved cell is a single
+// digit. The result can be loaded back with NewBoard
+func (b *Board) CandidateString() string {
+	r := make([]string, 81)
+	width := make([]int, 9)
+	for i, c := range b.fc {
+		for _, d := range c.candidates.digits() {
+			r[i] += strconv.Itoa(d)
+		}
+		if len(r[i]) > width[c.x] {
+			width[c.x] = len(r[i])
+		}
+	}
+
+	lines := []string{}
+	for y := 0; y < 9; y++ {
+		line := []string{}
+		for x := 0; x < 9; x++ {
+			line = append(line, fmt.Sprintf("%-*s", width[x], r[y*9+x]))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(line, " "), " "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// parseCandidates parses 81 candidate strings
+func parseCandidates(fields []string) ([][]float64, error) {
+	if len(fields) != 81 {
+		return nil, fmt.Errorf("candidate grid must have 81 cells, got %d", len(fields))
+	}
+
+	r := [][]float64{}
+	for i, f := range fields {
+		c := []float64{}
+		var seen mask
+		for _, ch := range f {
+			if ch < '1' || ch > '9' {
+				return nil, fmt.Errorf("cell %s: invalid candidate %q", Position{i % 9, i / 9}.Name(), ch)
+			}
+			if seen.has(int(ch - '0')) {
+				return nil, fmt.Errorf("cell %s: duplicate candidate %q", Position{i % 9, i / 9}.Name(), ch)
+			}
+			seen |= digitMask(int(ch - '0'))
+			c = append(c, float64(ch-'0'))
+		}
+		r = append(r, c)
+	}
+
+	return r, nil
+}
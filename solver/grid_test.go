@@ -0,0 +1,44 @@
+package solver
+
+import (
+	"io/ioutil"
+	"log"
+	"testing"
+)
+
+func TestCandidateString(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	b := NewBoard(log, mediumPuzzle)
+	b.solve(1, Selection{})
+
+	s := b.CandidateString()
+	r := NewBoard(log, s)
+	if r.CandidateString() != s || r.verboseString() != b.verboseString() {
+		t.Errorf("candidate grid doesn't round trip:\n%s\n\texpected:\n%s", r.CandidateString(), s)
+	}
+}
+
+func TestParseCandidates(t *testing.T) {
+	fields := []string{}
+	for i := 0; i < 81; i++ {
+		fields = append(fields, "123456789")
+	}
+
+	if _, err := parseCandidates(fields); err != nil {
+		t.Errorf("valid candidate grid is rejected: %s", err)
+	}
+
+	fields[10] = "1223"
+	if _, err := parseCandidates(fields); err == nil || err.Error() != `cell B2: duplicate candidate '2'` {
+		t.Errorf("duplicate candidate error is: %v", err)
+	}
+
+	fields[10] = "120"
+	if _, err := parseCandidates(fields); err == nil || err.Error() != `cell B2: invalid candidate '0'` {
+		t.Errorf("invalid candidate error is: %v", err)
+	}
+
+	if _, err := parseCandidates(fields[1:]); err == nil {
+		t.Errorf("candidate grid with 80 cells must be rejected")
+	}
+}
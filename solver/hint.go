@@ -0,0 +1,87 @@
+package solver
+
+import (
+	"context"
+	"strings"
+)
+
+// HintLevel defines how much of the next step a hint discloses
+type HintLevel int
+
+const (
+	// HintStrategy discloses only the name of the strategy to use
+	HintStrategy HintLevel = iota
+	// HintRegion discloses the strategy and where to look at
+	HintRegion
+	// HintFull discloses the whole deduction with its eliminations
+	HintFull
+)
+
+// Hint is the next logical step on the board
+type Hint struct {
+	Level    HintLevel `json:"-"`
+	Strategy string    `json:"strategy"`
+	// Region is the unit or the cells to look at, e.g. "row A" or "B3"
+	Region string `json:"region,omitempty"`
+	// Cells are the pattern cells of the step
+	Cells []Position `json:"cells,omitempty"`
+	// Step is the full deduction
+	Step *Step `json:"step,omitempty"`
+}
+
+// NextHint returns the first deduction found by the easiest applicable
+// strategy without applying it. Candidate cleanup isn't a hint, it's done
+// on a copy of the board before looking for one. The hint contains only
+// the information allowed by level. It returns false if no strategy
+// applies to the board or the cleanup changes nothing
+func NextHint(b *Board, level HintLevel) (Hint, bool) {
+	h, ok, _ := NextHintContext(context.Background(), b, level)
+
+	return h, ok
+}
+
+// NextHintContext finds a hint like NextHint, but stops with the error of
+// ctx when it's done
+func NextHintContext(ctx context.Context, b *Board, level HintLevel) (Hint, bool, error) {
+	c := b.Clone()
+	s, ok := Step{}, false
+	for !ok {
+		if err := ctx.Err(); err != nil {
+			return Hint{}, false, err
+		}
+		i, steps := c.findStrategies(len(c.strategies)-1, Selection{})
+		if i == 0 {
+			return Hint{}, false, nil
+		}
+		for _, st := range steps {
+			if rated(st) {
+				s, ok = st, true
+				break
+			}
+		}
+		if !ok && len(c.apply(steps)) == 0 {
+			return Hint{}, false, nil
+		}
+	}
+	h := Hint{Level: level, Strategy: s.Strategy}
+	if level < HintRegion {
+		return h, true, nil
+	}
+
+	h.Region = s.Unit
+	if h.Region == "" {
+		names := []string{}
+		for _, p := range s.Cells {
+			names = append(names, p.Name())
+		}
+		h.Region = strings.Join(names, ", ")
+	}
+	h.Cells = s.Cells
+	if level < HintFull {
+		return h, true, nil
+	}
+
+	h.Step = &s
+
+	return h, true, nil
+}
@@ -0,0 +1,101 @@
+package solver
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"testing"
+)
+
+func TestNextHint(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	b := NewBoard(log, "273964851469158723185273469821346597546792318397815246718529634632481975954637180")
+	before := b.verboseString()
+
+	h, ok := NextHint(b, HintStrategy)
+	if !ok || h.Strategy != "hidden singles" || h.Region != "" || h.Step != nil {
+		t.Errorf("strategy hint is: %+v, expected only strategy name", h)
+	}
+
+	h, ok = NextHint(b, HintRegion)
+	if !ok || h.Region != "row J" || len(h.Cells) != 1 || h.Cells[0] != (Position{8, 8}) || h.Step != nil {
+		t.Errorf("region hint is: %+v, expected row J", h)
+	}
+
+	h, ok = NextHint(b, HintFull)
+	if !ok || h.Step == nil || len(h.Step.Placements) != 1 || h.Step.Placements[0].Digit != 2 {
+		t.Errorf("full hint is: %+v, expected placement of 2 in J9", h)
+	}
+
+	if before != b.verboseString() {
+		t.Errorf("hint must not change the board")
+	}
+}
+
+func TestNextHintCleanup(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+	h, ok := NextHint(b, HintFull)
+	if !ok || h.Step == nil || len(h.Step.Placements) != 1 {
+		t.Errorf("first hint of a puzzle is: %+v, expected a placement", h.Step)
+	}
+
+	// hidden singles are stuck, naked singles clean up candidates before the placement
+	b.SolveContext(context.Background(), SolveOptions{Strategies: Selection{Include: []string{"hidden singles"}}})
+	before := b.CandidateString()
+	h, ok = NextHint(b, HintFull)
+	if !ok || h.Strategy != "naked singles" || len(h.Step.Placements) != 1 {
+		t.Errorf("hint is: %+v, expected a naked single placement", h.Step)
+	}
+	if b.CandidateString() != before {
+		t.Errorf("hint must not change the board")
+	}
+
+	// nothing to place, the hint is the strategy applying after the cleanup
+	b = NewBoard(nil, "000000000000000012000034000000000300005006400070100008000200070304000500600000000")
+	b.SolveContext(context.Background(), SolveOptions{Strategies: Selection{Include: []string{"hidden singles"}}})
+	h, ok = NextHint(b, HintFull)
+	if !ok || h.Strategy == "naked singles" && len(h.Step.Placements) == 0 {
+		t.Errorf("hint is: %+v, expected no candidate cleanup", h.Step)
+	}
+
+	// the cleanup alone is no hint
+	b = NewBoard(nil, hardPuzzle)
+	b.SolveContext(context.Background(), SolveOptions{Strategies: Selection{Include: []string{"hidden singles"}}})
+	if h, ok := NextHint(b, HintFull); ok {
+		t.Errorf("stuck board can't have hints: %+v", h.Step)
+	}
+}
+
+func TestNextHintNoProgress(t *testing.T) {
+	// a cleanup which eliminates nothing must not be retried forever
+	r := NewRegistry()
+	r.Unregister("naked singles")
+	r.Register(NewStrategy("naked singles", 2.3, func(*Board) []Step {
+		return []Step{{Cells: []Position{{0, 0}}, Eliminations: []Candidate{}}}
+	}))
+	r.Reorder("naked singles")
+	b := NewBoard(nil, mediumPuzzle)
+	b.UseStrategies(r)
+
+	if h, ok := NextHint(b, HintFull); ok {
+		t.Errorf("cleanup changing nothing can't be a hint: %+v", h.Step)
+	}
+}
+
+func TestNextHintContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if h, ok, err := NextHintContext(ctx, NewBoard(nil, mediumPuzzle), HintFull); err != context.Canceled || ok {
+		t.Errorf("hint: %+v, error: %v, expected the context error", h.Step, err)
+	}
+}
+
+func TestNextHintSolved(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	b := NewBoard(log, "273964851469158723185273469821346597546792318397815246718529634632481975954637182")
+
+	if h, ok := NextHint(b, HintFull); ok {
+		t.Errorf("solved board can't have hints: %+v", h)
+	}
+}
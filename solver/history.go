@@ -0,0 +1,65 @@
+package solver
+
+// Snapshot is the state of a board which can be restored later
+type Snapshot struct {
+	candidates [81]mask
+	rating     Rating
+	history    []Step
+}
+
+// Clone returns an independent copy of the board
+func (b *Board) Clone() *Board {
+	r := newBoard(b.log, func(x, y int) *Cell {
+		c := *b.cell(x, y)
+		return &c
+	})
+	r.strategies = b.strategies
+	r.rating = b.rating
+	r.puzzle = b.puzzle
+	r.history = append([]Step{}, b.history...)
+
+	return r
+}
+
+// Snapshot returns the current state of the board
+func (b *Board) Snapshot() Snapshot {
+	s := Snapshot{rating: b.rating, history: append([]Step{}, b.history...)}
+	for i, c := range b.fc {
+		s.candidates[i] = c.candidates
+	}
+
+	return s
+}
+
+// Restore returns the board to the state of the snapshot
+func (b *Board) Restore(s Snapshot) {
+	for i, c := range b.fc {
+		c.candidates = s.candidates[i]
+	}
+	b.indexPlaces()
+	b.rating = s.rating
+	b.history = append([]Step{}, s.history...)
+}
+
+// Undo reverts the last applied step by restoring the candidates it
+// eliminated and returns it. It returns false if no step is left to undo.
+// The rating isn't changed
+func (b *Board) Undo() (Step, bool) {
+	if len(b.history) == 0 {
+		return Step{}, false
+	}
+
+	s := b.history[len(b.history)-1]
+	b.history = b.history[:len(b.history)-1]
+	for _, e := range s.Eliminations {
+		b.include(b.cell(e.X, e.Y), digitMask(e.Digit))
+	}
+
+	return s, true
+}
+
+// History returns the applied steps which can be undone, the last one first
+// to be undone
+func (b *Board) History() []Step {
+	return append([]Step{}, b.history...)
+}
@@ -0,0 +1,72 @@
+package solver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+	c := b.Clone()
+
+	r := c.Solve()
+	if !r.Solved || r.Puzzle != b.codeStr() {
+		t.Errorf("clone solved: %v, puzzle: %s", r.Solved, r.Puzzle)
+	}
+	if b.codeStr() != r.Puzzle || b.rating.Steps != 0 || len(b.History()) != 0 {
+		t.Errorf("solving the clone changed the board: %s", b.codeStr())
+	}
+
+	b.Solve()
+	c = b.Clone()
+	if c.CandidateString() != b.CandidateString() || c.rating != b.rating || c.puzzle != b.puzzle || c.places != b.places {
+		t.Errorf("clone of a solved board differs")
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+	initial := b.CandidateString()
+	s := b.Snapshot()
+
+	b.Solve()
+	b.Restore(s)
+	if b.CandidateString() != initial || b.rating != (Rating{}) || len(b.History()) != 0 {
+		t.Errorf("restored board differs from the snapshot")
+	}
+
+	places := b.places
+	b.indexPlaces()
+	if places != b.places {
+		t.Errorf("places index is out of sync after restore")
+	}
+}
+
+func TestUndo(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+	initial := b.CandidateString()
+
+	r := b.Solve()
+	if h := b.History(); !reflect.DeepEqual(h, r.Steps) {
+		t.Fatalf("history has %d steps, expected the %d solving steps", len(h), len(r.Steps))
+	}
+
+	for i := len(r.Steps) - 1; i >= 0; i-- {
+		s, ok := b.Undo()
+		if !ok || !reflect.DeepEqual(s, r.Steps[i]) {
+			t.Fatalf("undo %d returned %v, expected %v", i, s, r.Steps[i])
+		}
+	}
+	if _, ok := b.Undo(); ok {
+		t.Errorf("undo must fail without history")
+	}
+	if b.CandidateString() != initial {
+		t.Errorf("undoing all steps must restore the puzzle:\n%s", b.CandidateString())
+	}
+
+	places := b.places
+	b.indexPlaces()
+	if places != b.places {
+		t.Errorf("places index is out of sync after undo")
+	}
+}
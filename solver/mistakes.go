@@ -0,0 +1,48 @@
+package solver
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Kinds of mistakes
+const (
+	// MistakeWrongValue is a cell solved with a wrong digit
+	MistakeWrongValue = "wrong value"
+	// MistakeRemovedCandidate is a correct digit removed from cell candidates
+	MistakeRemovedCandidate = "removed candidate"
+)
+
+// Mistake is a difference between the board and the puzzle solution
+type Mistake struct {
+	// Candidate is the wrong digit for a wrong value,
+	// and the correct digit for a removed candidate
+	Candidate
+	Kind string `json:"kind"`
+}
+
+// Mistakes checks the board against the puzzle solution, an 81 digit string,
+// and reports wrong placements and wrongly removed candidates
+func (b *Board) Mistakes(solution string) []Mistake {
+	if len(solution) != 81 {
+		panic(errors.New("invalid Sudoku solution"))
+	}
+
+	r := []Mistake{}
+	for i, c := range b.fc {
+		v, err := strconv.Atoi(solution[i : i+1])
+		if err != nil || v == 0 {
+			panic(errors.New("invalid Sudoku solution"))
+		}
+
+		p := Position{c.x, c.y}
+		switch {
+		case c.isSolved() && c.value() != v:
+			r = append(r, Mistake{Candidate{p, c.value()}, MistakeWrongValue})
+		case !c.isCandidate(v):
+			r = append(r, Mistake{Candidate{p, v}, MistakeRemovedCandidate})
+		}
+	}
+
+	return r
+}
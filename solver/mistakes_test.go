@@ -0,0 +1,61 @@
+package solver
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"testing"
+)
+
+func TestMistakes(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	solution := "273964851469158723185273469821346597546792318397815246718529634632481975954637182"
+
+	candidates := [][]float64{}
+	for _, ch := range solution {
+		candidates = append(candidates, []float64{float64(ch - '0')})
+	}
+	candidates[0] = []float64{3}
+	candidates[1] = []float64{1, 2, 5}
+	candidates[2] = []float64{3, 4}
+	b := NewBoardFromCandidates(log, candidates)
+
+	ex := []Mistake{
+		{Candidate{Position{0, 0}, 3}, MistakeWrongValue},
+		{Candidate{Position{1, 0}, 7}, MistakeRemovedCandidate},
+	}
+	if r := b.Mistakes(solution); !reflect.DeepEqual(r, ex) {
+		t.Errorf("mistakes: %+v, expected: %+v", r, ex)
+	}
+}
+
+func TestNewBoardFromCandidates(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	candidates := [][]float64{}
+	for i := 0; i < 81; i++ {
+		candidates = append(candidates, MakeRange(1, 10))
+	}
+	candidates[80] = []float64{2, 7}
+	b := NewBoardFromCandidates(log, candidates)
+
+	if c := b.cell(8, 8); !reflect.DeepEqual(c.candidates.digits(), []int{2, 7}) {
+		t.Errorf("J9 candidates: %v, expected: %v", c.candidates.digits(), []int{2, 7})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("cell without candidates must be rejected")
+		}
+	}()
+	candidates[0] = []float64{}
+	NewBoardFromCandidates(log, candidates)
+}
+
+func TestMistakeJSON(t *testing.T) {
+	m := Mistake{Candidate{Position{0, 1}, 3}, MistakeWrongValue}
+	data, err := json.Marshal(m)
+	if err != nil || string(data) != `{"x":0,"y":1,"digit":3,"kind":"wrong value"}` {
+		t.Errorf("mistake JSON: %s, error: %v", data, err)
+	}
+}
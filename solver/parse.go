@@ -0,0 +1,153 @@
+package solver
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// blanks are the characters accepted for empty cells
+const blanks = ".0-*_"
+
+// gridChars are the characters used to draw grids,
+// '-' is also a blank and is resolved by context
+const gridChars = "|+=:-"
+
+// Parse creates a board from a puzzle string. It accepts 81 givens in any
+// common layout (see ParseGivens) or a candidate grid as returned by
+// CandidateString
+func Parse(l *log.Logger, s string) (*Board, error) {
+	if fields, ok := candidateFields(s); ok {
+		candidates, err := parseCandidates(fields)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewBoardFromCandidates(l, candidates), nil
+	}
+
+	givens, err := ParseGivens(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBoard(l, func(x, y int) *Cell {
+		return NewCellFromInt(x, y, givens[y*9+x])
+	}), nil
+}
+
+// ParseGivens parses 81 givens row by row and returns them as digits,
+// 0 is an empty cell. Empty cells can be any of '.', '0', '-', '*' or '_'.
+// Whitespace and grid drawing characters ('|', '+', '=', ':' and lines
+// of '-') are ignored, so both single line strings and pretty grids pasted
+// from forums are accepted. Frame lines with stars at the corners, e.g.
+// "*-----------*", are ignored as well. A line of nine dashes can be either a row of
+// empty cells or a separator; it is resolved by the total number of cells
+// and rejected if that doesn't decide
+func ParseGivens(s string) ([]int, error) {
+	type line struct {
+		n      int
+		cells  []int
+		dashes bool
+	}
+
+	lines := []line{}
+	numCells, numDashes := 0, 0
+	for n, l := range strings.Split(s, "\n") {
+		if isFrame(l) {
+			continue
+		}
+		cells := []int{}
+		dashes, frame := true, false
+		for col, ch := range []rune(l) {
+			switch {
+			case ch >= '1' && ch <= '9':
+				cells = append(cells, int(ch-'0'))
+				dashes = false
+			case strings.ContainsRune(blanks, ch):
+				cells = append(cells, 0)
+				dashes = dashes && ch == '-'
+			case unicode.IsSpace(ch) || strings.ContainsRune(gridChars, ch):
+				frame = frame || strings.ContainsRune("+=:", ch)
+			default:
+				return nil, fmt.Errorf("line %d, column %d: unexpected character %q", n+1, col+1, ch)
+			}
+		}
+
+		switch {
+		case len(cells) == 0:
+			// whitespace or grid drawing only
+		case dashes && (frame || len(cells) != 9 && len(cells) != 81):
+			// a separator, e.g. "-----------" or "+-------+"
+		case dashes:
+			lines = append(lines, line{n + 1, cells, true})
+			numDashes += len(cells)
+		default:
+			lines = append(lines, line{n + 1, cells, false})
+			numCells += len(cells)
+		}
+	}
+
+	// a line of dashes is either a separator or a row of empty cells
+	dashRows := false
+	switch {
+	case numCells == 81:
+	case numDashes > 0 && numCells+numDashes == 81:
+		dashRows = true
+	case numDashes > 0:
+		for _, l := range lines {
+			if l.dashes {
+				return nil, fmt.Errorf("line %d: ambiguous line of dashes, puzzle has %d cells without it", l.n, numCells)
+			}
+		}
+	case len(lines) > 1:
+		// rows have 9 cells, the first line which doesn't is likely wrong
+		for _, l := range lines {
+			if len(l.cells) != 9 {
+				return nil, fmt.Errorf("line %d: puzzle must have 81 cells, got %d with %d on the line", l.n, numCells, len(l.cells))
+			}
+		}
+		fallthrough
+	default:
+		return nil, fmt.Errorf("puzzle must have 81 cells, got %d", numCells)
+	}
+
+	r := []int{}
+	for _, l := range lines {
+		if !l.dashes || dashRows {
+			r = append(r, l.cells...)
+		}
+	}
+
+	return r, nil
+}
+
+// isFrame reports whether the line is a frame drawn with a star at each
+// end, e.g. "*-----------*". Stars are empty cells elsewhere, so a line
+// of stars only is a row
+func isFrame(l string) bool {
+	l = strings.TrimSpace(l)
+
+	return len(l) > 1 && l[0] == '*' && l[len(l)-1] == '*' &&
+		strings.Trim(l, "*-+|") == "" && strings.Trim(l, "*") != ""
+}
+
+// candidateFields returns the cells of a candidate grid ignoring grid
+// drawing tokens. It returns false if s isn't a candidate grid
+func candidateFields(s string) ([]string, bool) {
+	fields := []string{}
+	multi := false
+	for _, f := range strings.Fields(s) {
+		if strings.Trim(f, gridChars+".*'") == "" && (len(f) > 1 || strings.ContainsAny(f, "|+=:")) {
+			continue
+		}
+		if strings.Trim(f, "0123456789") != "" {
+			return nil, false
+		}
+		multi = multi || len(f) > 1
+		fields = append(fields, f)
+	}
+
+	return fields, len(fields) == 81 && multi
+}
@@ -0,0 +1,115 @@
+package solver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGivens(t *testing.T) {
+	givens := mediumPuzzle
+	ex := []int{}
+	for _, ch := range givens {
+		ex = append(ex, int(ch-'0'))
+	}
+
+	puzzles := []string{
+		givens,
+		strings.NewReplacer("0", ".").Replace(givens[:27]) +
+			strings.NewReplacer("0", "*").Replace(givens[27:54]) + "\n" +
+			strings.NewReplacer("0", "_").Replace(givens[54:]),
+		`
++-------+-------+-------+
+| . . . | . . . | . . 1 |
+| . . . | . . . | . 2 3 |
+| . . 4 | . . 5 | . . . |
++-------+-------+-------+
+| . . . | . . 2 | . . . |
+| . 1 . | . . . | 4 . . |
+| 3 6 . | . 7 . | . . . |
++-------+-------+-------+
+| . . . | 6 1 . | . . . |
+| . . 5 | . . . | 8 . . |
+| . . 7 | . 3 . | . . . |
++-------+-------+-------+
+`,
+		`
+--- --- --1
+--- --- -23
+--4 --5 ---
+-----------
+--- --2 ---
+-1- --- 4--
+36- -7- ---
+-----------
+--- 61- ---
+--5 --- 8--
+--7 -3- ---
+`,
+		`*-----------*
+|...|...|..1|
+|...|...|.23|
+|..4|..5|...|
+|---+---+---|
+|...|..2|...|
+|.1.|...|4..|
+|36.|.7.|...|
+|---+---+---|
+|...|61.|...|
+|..5|...|8..|
+|..7|.3.|...|
+*-----------*
+`,
+	}
+
+	for _, p := range puzzles {
+		r, err := ParseGivens(p)
+		if err != nil || !reflect.DeepEqual(r, ex) {
+			t.Errorf("%s\n\tparsed: %v, error: %v", p, r, err)
+		}
+	}
+}
+
+func TestParseGivensDashRows(t *testing.T) {
+	p := "---------\n" + strings.Repeat("123456789\n", 8)
+	r, err := ParseGivens(p)
+	if err != nil || len(r) != 81 || r[0] != 0 || r[9] != 1 {
+		t.Errorf("row of dashes must be empty cells, parsed: %v, error: %v", r, err)
+	}
+
+	p = "---------\n" + strings.Repeat("123456789\n", 9)
+	r, err = ParseGivens(p)
+	if err != nil || len(r) != 81 || r[0] != 1 {
+		t.Errorf("line of dashes must be a separator, parsed: %v, error: %v", r, err)
+	}
+
+	p = "---------\n" + strings.Repeat("123456789\n", 7)
+	if _, err = ParseGivens(p); err == nil || err.Error() != "line 1: ambiguous line of dashes, puzzle has 63 cells without it" {
+		t.Errorf("ambiguous line error is: %v", err)
+	}
+}
+
+func TestParseGivensErrors(t *testing.T) {
+	errors := map[string]string{
+		"12345678x" + strings.Repeat("0", 72): `line 1, column 9: unexpected character 'x'`,
+		strings.Repeat("0", 9) + "\n 0a":      `line 2, column 3: unexpected character 'a'`,
+		strings.Repeat("0", 80):               `puzzle must have 81 cells, got 80`,
+		strings.Repeat("0", 82):               `puzzle must have 81 cells, got 82`,
+		strings.Repeat("000000000\n", 4) + "0000000000\n" + strings.Repeat("000000000\n", 4): `line 5: puzzle must have 81 cells, got 82 with 10 on the line`,
+		strings.Repeat("000000000\n", 8): `puzzle must have 81 cells, got 72`,
+	}
+
+	for p, ex := range errors {
+		if _, err := ParseGivens(p); err == nil || err.Error() != ex {
+			t.Errorf("%q\n\terror is: %v, expected: %s", p, err, ex)
+		}
+	}
+}
+
+func TestParseCandidateGrid(t *testing.T) {
+	p := strings.Repeat("| 12 3 4 | 5 6 7 | 8 9 1 |\n", 9)
+	b, err := Parse(nil, p)
+	if err != nil || b.cell(0, 0).candidates != maskOf(1, 2) {
+		t.Errorf("candidate grid is not parsed, error: %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package solver
+
+import (
+	"context"
+	"reflect"
+	"sort"
+)
+
+// findSimplest returns the index of the strategy and the single deduction
+// with the lowest difficulty among all selected strategies. Deductions of
+// the same difficulty are compared by their effect. The board isn't changed
+func (b *Board) findSimplest(maxDifficulty int, sel Selection) (int, []Step) {
+	if b.isSolved() {
+		return 0, nil
+	}
+
+	order := []int{}
+	for i := 1; i < len(b.strategies) && i <= maxDifficulty; i++ {
+		if sel.allows(b.strategies[i].Name()) {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return b.strategies[order[i]].Difficulty() < b.strategies[order[j]].Difficulty()
+	})
+
+	best := 0
+	var bestStep Step
+	for _, i := range order {
+		if best > 0 && b.strategies[i].Difficulty() > b.strategies[best].Difficulty() {
+			break
+		}
+
+		b.logf("Try %s", b.strategies[i].Name())
+		for _, s := range b.strategies[i].Apply(b) {
+			if best == 0 || biggerEffect(s, bestStep) {
+				best, bestStep = i, s
+			}
+		}
+	}
+	if best == 0 {
+		return 0, nil
+	}
+	bestStep.Strategy = b.strategies[best].Name()
+
+	return best, []Step{bestStep}
+}
+
+// biggerEffect reports whether step a solves more cells than step b,
+// or eliminates more candidates when they solve as many
+func biggerEffect(a, b Step) bool {
+	if len(a.Placements) != len(b.Placements) {
+		return len(a.Placements) > len(b.Placements)
+	}
+
+	return len(a.Eliminations) > len(b.Eliminations)
+}
+
+// compact drops the steps the solution doesn't depend on. From the last
+// step, it replays the path without the step and drops it if all other
+// steps are still found and solve the board. It returns the compacted
+// path and leaves the board in the state it reaches. The path is kept
+// as is if it can't be replayed, compaction stops early when ctx is done
+func (b *Board) compact(ctx context.Context, start Snapshot, steps []Step) []Step {
+	end := b.Snapshot()
+	// states[i] is the board before steps[i], the steps before i don't
+	// change when a later step is dropped
+	states := []Snapshot{}
+	b.Restore(start)
+	replayed := []Step{}
+	for _, s := range steps {
+		states = append(states, b.Snapshot())
+		r, ok := b.replayStep(s)
+		if !ok {
+			b.Restore(end)
+			return steps
+		}
+		if len(r) == 0 {
+			states = states[:len(states)-1]
+		}
+		replayed = append(replayed, r...)
+	}
+	if !b.isSolved() || len(replayed) == 0 {
+		b.Restore(end)
+		return steps
+	}
+	steps = replayed
+
+	for i := len(steps) - 1; i >= 0 && ctx.Err() == nil; i-- {
+		if r, ok := b.replay(states[i], steps[i+1:]); ok {
+			steps = append(steps[:i:i], r...)
+		}
+	}
+	b.replay(states[0], steps)
+
+	return steps
+}
+
+// replay restores from and applies the steps again. It returns the
+// applied steps, which can eliminate less or more than before, and
+// whether they solve the board
+func (b *Board) replay(from Snapshot, steps []Step) ([]Step, bool) {
+	b.Restore(from)
+	applied := []Step{}
+	for _, s := range steps {
+		r, ok := b.replayStep(s)
+		if !ok {
+			return nil, false
+		}
+		applied = append(applied, r...)
+	}
+
+	return applied, b.isSolved()
+}
+
+// replayStep applies the step again, it must still be found by its
+// strategy unless it has nothing left to eliminate
+func (b *Board) replayStep(s Step) ([]Step, bool) {
+	found, ok := b.refind(s)
+	if !ok {
+		// an earlier step eliminated more than before
+		return nil, b.eliminated(s)
+	}
+
+	return b.apply([]Step{found}), true
+}
+
+// eliminated reports whether none of the eliminations of the step is
+// a candidate of the board
+func (b *Board) eliminated(s Step) bool {
+	for _, e := range s.Eliminations {
+		if b.cell(e.X, e.Y).isCandidate(e.Digit) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// refind looks for the deduction of the step on the current board. The
+// strategy must find a deduction in the same unit and cells, preferably
+// of the same digits: the digits of a naked single depend on the order
+// the other steps are applied in
+func (b *Board) refind(s Step) (Step, bool) {
+	i := b.strategyIndex(s.Strategy)
+	if i == 0 {
+		return Step{}, false
+	}
+
+	var r Step
+	found := false
+	for _, f := range b.strategies[i].Apply(b) {
+		if f.Unit != s.Unit || !reflect.DeepEqual(f.Cells, s.Cells) {
+			continue
+		}
+		f.Strategy = s.Strategy
+		if reflect.DeepEqual(f.Digits, s.Digits) {
+			return f, true
+		}
+		if !found {
+			r, found = f, true
+		}
+	}
+
+	return r, found
+}
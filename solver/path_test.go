@@ -0,0 +1,86 @@
+package solver
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSolveSimplest(t *testing.T) {
+	full := NewBoard(nil, mediumPuzzle).Solve()
+
+	b := NewBoard(nil, mediumPuzzle)
+	res := b.SolveContext(context.Background(), SolveOptions{Simplest: true})
+	if !res.Solved || res.Grid != full.Grid {
+		t.Fatalf("solved: %v, grid: %s, expected: %s", res.Solved, res.Grid, full.Grid)
+	}
+	for i := 1; i < len(res.Steps); i++ {
+		if res.Steps[i].Strategy == "hidden singles" {
+			break
+		}
+		if res.Steps[i].Strategy != "naked singles" {
+			t.Fatalf("hidden singles must be used before %s", res.Steps[i])
+		}
+	}
+	if res.Rating.Max != full.Rating.Max || res.Rating.Steps != ratedSteps(res.Steps) {
+		t.Errorf("rating: %+v, steps: %d, expected max %.1f", res.Rating, len(res.Steps), full.Rating.Max)
+	}
+}
+
+func TestFindSimplest(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+	// empty cells have all candidates before the first naked singles
+	b.apply(SolveStripNakedSingles(b))
+	i, steps := b.findSimplest(len(b.strategies)-1, Selection{})
+	if i != b.strategyIndex("hidden singles") || len(steps) != 1 {
+		t.Fatalf("strategy: %d, steps: %v", i, steps)
+	}
+
+	for _, s := range b.strategies[i].Apply(b) {
+		if biggerEffect(s, steps[0]) {
+			t.Errorf("%s has a bigger effect than %s", s, steps[0])
+		}
+	}
+}
+
+func TestSolveCompact(t *testing.T) {
+	for _, o := range []SolveOptions{{Compact: true}, {Compact: true, Simplest: true}, {Compact: true, Propagate: true}} {
+		full := NewBoard(nil, mediumPuzzle).SolveContext(context.Background(), SolveOptions{Simplest: o.Simplest})
+
+		res := NewBoard(nil, mediumPuzzle).SolveContext(context.Background(), o)
+		if !res.Solved || res.Grid != full.Grid {
+			t.Fatalf("%+v: solved: %v, grid: %s", o, res.Solved, res.Grid)
+		}
+		if len(res.Steps) >= len(full.Steps) || res.Rating.Steps != ratedSteps(res.Steps) {
+			t.Errorf("%+v: steps: %d, uncompacted: %d, rating: %+v", o, len(res.Steps), len(full.Steps), res.Rating)
+		}
+
+		// the compacted path alone solves the puzzle
+		b := NewBoard(nil, mediumPuzzle)
+		for _, s := range res.Steps {
+			found, ok := b.refind(s)
+			if !ok {
+				t.Fatalf("%+v: step is not found on the board: %s", o, s)
+			}
+			b.apply([]Step{found})
+		}
+		if !b.isSolved() {
+			t.Errorf("%+v: the compacted path doesn't solve the puzzle: %s", o, b.codeStr())
+		}
+	}
+}
+
+func TestSolveCompactUnsolved(t *testing.T) {
+	full := NewBoard(nil, hardPuzzle).SolveContext(context.Background(), SolveOptions{})
+	res := NewBoard(nil, hardPuzzle).SolveContext(context.Background(), SolveOptions{Compact: true})
+	if len(res.Steps) != len(full.Steps) || res.Grid != full.Grid {
+		t.Errorf("a path not solving the board must be kept, steps: %d, expected: %d", len(res.Steps), len(full.Steps))
+	}
+}
+
+func TestSolveCompactSolved(t *testing.T) {
+	solved := "812753649943682175675491283154237896369845721287169534521974368438526917796318452"
+	res := NewBoard(nil, solved).SolveContext(context.Background(), SolveOptions{Compact: true})
+	if !res.Solved || len(res.Steps) != 0 {
+		t.Errorf("solved: %v, steps: %d", res.Solved, len(res.Steps))
+	}
+}
@@ -0,0 +1,58 @@
+package solver
+
+import "math/bits"
+
+// cellSet is a set of cells of a unit, bit i is set for the i-th cell of the unit
+type cellSet uint16
+
+func (s cellSet) count() int {
+	return bits.OnesCount16(uint16(s))
+}
+
+// indexPlaces rebuilds the places index from the cell candidates
+func (b *Board) indexPlaces() {
+	b.places = [27][9]cellSet{}
+	for i, c := range b.fc {
+		for _, up := range cellUnits[i] {
+			for v := uint16(c.candidates); v != 0; v &= v - 1 {
+				b.places[up.unit][bits.TrailingZeros16(v)] |= 1 << uint(up.pos)
+			}
+		}
+	}
+}
+
+// eliminate removes the candidates m from the cell and updates the places
+// index. All eliminations on the board must go through it. It returns true
+// if any candidate was removed
+func (b *Board) eliminate(c *Cell, m mask) bool {
+	removed := c.candidates & m
+	if !c.exclude(removed) {
+		return false
+	}
+
+	for _, up := range cellUnits[c.y*9+c.x] {
+		for v := uint16(removed); v != 0; v &= v - 1 {
+			b.places[up.unit][bits.TrailingZeros16(v)] &^= 1 << uint(up.pos)
+		}
+	}
+
+	return true
+}
+
+// include adds the candidates m back to the cell and updates the places index
+func (b *Board) include(c *Cell, m mask) {
+	added := m &^ c.candidates
+	c.candidates |= added
+
+	for _, up := range cellUnits[c.y*9+c.x] {
+		for v := uint16(added); v != 0; v &= v - 1 {
+			b.places[up.unit][bits.TrailingZeros16(v)] |= 1 << uint(up.pos)
+		}
+	}
+}
+
+// placesOf returns the cells of unit u where digit d is a candidate,
+// solved cells included
+func (b *Board) placesOf(u, d int) cellSet {
+	return b.places[u][d-1]
+}
@@ -0,0 +1,34 @@
+package solver
+
+import "testing"
+
+func TestPlacesIndex(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+
+	// empty cells start with all candidates, A9 is given 1
+	if s := b.placesOf(0, 1); s != 0b111111111 {
+		t.Errorf("places of 1 in row A: %09b, expected all cells", s)
+	}
+	if s := b.placesOf(0, 2); s != 0b011111111 {
+		t.Errorf("places of 2 in row A: %09b, expected all cells but A9", s)
+	}
+
+	b.Apply(Step{Eliminations: []Candidate{{Position{0, 0}, 2}, {Position{1, 0}, 2}}})
+	if s := b.placesOf(0, 2); s&0b11 != 0 {
+		t.Errorf("places of 2 in row A: %09b, A1 and A2 must be removed", s)
+	}
+	if s := b.placesOf(18, 2); s&0b11 != 0 {
+		t.Errorf("places of 2 in block 1: %09b, A1 and A2 must be removed", s)
+	}
+	if s := b.placesOf(9, 2); s&1 != 0 {
+		t.Errorf("places of 2 in column 1: %09b, A1 must be removed", s)
+	}
+
+	// the index must stay in sync with the candidates while solving
+	b.Solve()
+	index := b.places
+	b.indexPlaces()
+	if index != b.places {
+		t.Errorf("places index is out of sync with the candidates")
+	}
+}
@@ -0,0 +1,68 @@
+package solver
+
+import "fmt"
+
+// nakedSinglesName is the name of the naked singles strategy, propagation
+// steps are naked singles
+const nakedSinglesName = "naked singles"
+
+// solvedCells returns the indexes of solved cells
+func (b *Board) solvedCells() []int {
+	r := []int{}
+	for i, c := range b.fc {
+		if c.isSolved() {
+			r = append(r, i)
+		}
+	}
+
+	return r
+}
+
+// appendPlacements appends the cells solved by steps to queue
+func appendPlacements(queue []int, steps []Step) []int {
+	for _, s := range steps {
+		for _, p := range s.Placements {
+			queue = append(queue, p.Y*9+p.X)
+		}
+	}
+
+	return queue
+}
+
+// propagate removes the digits of the solved cells in queue from their
+// peers. It never removes the last but one candidate of a peer: placing a
+// digit is left to the strategies, so the solving path and its rating are
+// the same as without propagation. Every cell removing a digit makes
+// a step, at most limit steps are made unless limit is 0
+func (b *Board) propagate(queue *[]int, limit int) []Step {
+	steps := []Step{}
+	for len(*queue) > 0 && (limit == 0 || len(steps) < limit) {
+		c := b.fc[(*queue)[0]]
+		*queue = (*queue)[1:]
+		if !c.isSolved() {
+			continue
+		}
+
+		v := c.value()
+		s := Step{
+			Strategy:     nakedSinglesName,
+			Cells:        []Position{{c.x, c.y}},
+			Digits:       []int{v},
+			Eliminations: []Candidate{},
+			Description:  fmt.Sprintf("Cell %s is %d, so none of its peers can be %d", c.cellName(), v, v),
+		}
+		for _, p := range b.peers[c.y*9+c.x] {
+			if p.isCandidate(v) && (p.candidates&^digitMask(v)).count() > 1 {
+				s.Eliminations = append(s.Eliminations, Candidate{Position{p.x, p.y}, v})
+			}
+		}
+
+		applied := b.apply([]Step{s})
+		for _, s := range applied {
+			b.logf(" * %s", s)
+		}
+		steps = append(steps, applied...)
+	}
+
+	return steps
+}
@@ -0,0 +1,56 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestPropagate(t *testing.T) {
+	// every empty cell is a naked single
+	puzzle := "8.27536499436821756754.1283154237896369845721287169534521974368438526917796318..."
+	out := &bytes.Buffer{}
+
+	r := NewBoard(log.New(out, "", 0), puzzle).SolveContext(context.Background(), SolveOptions{Propagate: true})
+	if !r.Solved || r.Grid != "812753649943682175675491283154237896369845721287169534521974368438526917796318452" {
+		t.Fatalf("solved: %v, grid: %s", r.Solved, r.Grid)
+	}
+	if !strings.Contains(out.String(), "Cell A1 is 8, so none of its peers can be 8") {
+		t.Errorf("peers must be cleaned up by propagation:\n%s", out)
+	}
+	for _, s := range r.Steps {
+		if s.Strategy == nakedSinglesName && len(s.Placements) == 0 && len(s.Eliminations) == 0 {
+			t.Errorf("unexpected step: %+v", s)
+		}
+	}
+	if ex := NewBoard(nil, puzzle).Solve(); r.Rating != ex.Rating {
+		t.Errorf("rating: %+v, expected %+v", r.Rating, ex.Rating)
+	}
+}
+
+func TestPropagateSolvesLikeRescanning(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	puzzles := []string{
+		mediumPuzzle,
+		hardPuzzle,
+	}
+	for i := 0; i < 30; i++ {
+		puzzles = append(puzzles, Generate(rng))
+	}
+	for _, p := range puzzles {
+		ex := NewBoard(nil, p).Solve()
+		r := NewBoard(nil, p).SolveContext(context.Background(), SolveOptions{Propagate: true})
+		if r.Grid != ex.Grid || r.Candidates != ex.Candidates || r.Rating != ex.Rating || r.HardestStrategy != ex.HardestStrategy {
+			t.Errorf("%s: propagation solved to %s by %s rated %+v, expected %s by %s rated %+v",
+				p, r.Grid, r.HardestStrategy, r.Rating, ex.Grid, ex.HardestStrategy, ex.Rating)
+		}
+	}
+
+	r := NewBoard(nil, "8.27536499436821756754.1283154237896369845721287169534521974368438526917796318...").SolveContext(context.Background(), SolveOptions{Propagate: true, MaxSteps: 2})
+	if len(r.Steps) != 2 || r.StopReason != StopMaxSteps {
+		t.Errorf("steps: %d, stop reason: %s", len(r.Steps), r.StopReason)
+	}
+}
@@ -0,0 +1,89 @@
+package solver
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// Difficulty levels used to bucket puzzles by their rating.
+const (
+	LevelEasy   = "Easy"
+	LevelMedium = "Medium"
+	LevelHard   = "Hard"
+	LevelExpert = "Expert"
+)
+
+// levels maps the upper bound (exclusive) of the hardest step to the level name
+var levels = []struct {
+	max  float64
+	name string
+}{
+	{2.5, LevelEasy},
+	{3.5, LevelMedium},
+	{5.0, LevelHard},
+}
+
+// isLevel reports whether level is one of the Level constants or empty
+func isLevel(level string) bool {
+	for _, l := range levels {
+		if l.name == level {
+			return true
+		}
+	}
+
+	return level == "" || level == LevelExpert
+}
+
+// Rating is a difficulty rating compatible with the Sudoku Explainer (SE) scale
+type Rating struct {
+	// Max is the difficulty of the hardest step, i.e. the SE rating of the puzzle
+	Max float64 `json:"max"`
+	// Total is the sum of difficulties of all steps, the overall solving effort
+	Total float64 `json:"total"`
+	// Steps is the number of steps used
+	Steps int `json:"steps"`
+	// Solved reports whether the puzzle was solved with the available strategies
+	Solved bool `json:"solved"`
+}
+
+// Level returns the difficulty bucket of the rating
+func (r Rating) Level() string {
+	for _, l := range levels {
+		if r.Max < l.max {
+			return l.name
+		}
+	}
+
+	return LevelExpert
+}
+
+// MarshalJSON encodes the rating with its level
+func (r Rating) MarshalJSON() ([]byte, error) {
+	type rating Rating
+	return json.Marshal(struct {
+		rating
+		Level string `json:"level"`
+	}{rating(r), r.Level()})
+}
+
+// rated reports whether the step counts toward the rating. Naked singles
+// steps which don't place a digit only clean up the candidates seen from
+// solved cells, like Sudoku Explainer they aren't rated
+func rated(s Step) bool {
+	return s.Strategy != nakedSinglesName || len(s.Placements) > 0
+}
+
+func (r *Rating) add(s Strategy) {
+	if s.Difficulty() > r.Max {
+		r.Max = s.Difficulty()
+	}
+	r.Total = math.Round((r.Total+s.Difficulty())*10) / 10
+	r.Steps++
+}
+
+// Rate solves the board using all strategies and returns its rating
+func (b *Board) Rate() Rating {
+	b.solve(len(b.strategies)-1, Selection{})
+
+	return b.rating
+}
@@ -0,0 +1,61 @@
+package solver
+
+import (
+	"io/ioutil"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestRate(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	b := NewBoard(log, mediumPuzzle)
+
+	r := b.Rate()
+	if !r.Solved || r.Max != 3.4 || r.Level() != LevelMedium {
+		t.Errorf("hidden pairs puzzle rating is: %+v, expected: 3.4 (%s)", r, LevelMedium)
+	}
+	if r.Steps == 0 || r.Total < r.Max {
+		t.Errorf("total effort %v must be at least the hardest step %v", r.Total, r.Max)
+	}
+}
+
+func TestRatingLevel(t *testing.T) {
+	ex := map[float64]string{
+		1.5: LevelEasy,
+		2.3: LevelEasy,
+		3.0: LevelMedium,
+		4.0: LevelHard,
+		5.4: LevelExpert,
+	}
+
+	for max, level := range ex {
+		if r := (Rating{Max: max}); r.Level() != level {
+			t.Errorf("rating %v has level: %s, expected: %s", max, r.Level(), level)
+		}
+	}
+}
+
+func TestRateSingles(t *testing.T) {
+	// needs only singles, Sudoku Explainer rates it 1.5
+	puzzle := "003020600900305001001806400008102900700000008006708200002609500800203009005010300"
+	b := NewBoard(nil, puzzle)
+
+	r := b.Rate()
+	empty := strings.Count(puzzle, "0")
+	if !r.Solved || r.Max != 1.5 || r.Steps != empty || r.Total != 1.5*float64(empty) {
+		t.Errorf("singles puzzle with %d empty cells rating is: %+v, expected: 1.5 for each cell", empty, r)
+	}
+}
+
+// ratedSteps returns the number of steps counted by the rating
+func ratedSteps(steps []Step) int {
+	n := 0
+	for _, s := range steps {
+		if rated(s) {
+			n++
+		}
+	}
+
+	return n
+}
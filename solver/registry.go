@@ -0,0 +1,169 @@
+package solver
+
+import "fmt"
+
+// nothing is the strategy at index 0 of every board, it stands for no strategy
+var nothing = NewStrategy("nothing", 0, NothingStrategy)
+
+// builtinStrategies returns the strategies of the package from the easiest one
+func builtinStrategies() []Strategy {
+	return []Strategy{
+		NewStrategy("hidden singles", 1.5, SolveHiddenSingles),
+		NewStrategy("naked singles", 2.3, SolveStripNakedSingles),
+		NewStrategy("naked pairs", 3.0, SolveNakedPairs),
+		NewStrategy("hidden pairs", 3.4, SolveHiddenPairs),
+		NewStrategy("naked triples", 3.6, SolveNakedTriples),
+		NewStrategy("hidden triples", 4.0, SolveHiddenTriples),
+		NewStrategy("naked quads", 5.0, SolveNakedQuads),
+		NewStrategy("hidden quads", 5.4, SolveHiddenQuads),
+	}
+}
+
+// Registry is an ordered list of strategies. A board tries the enabled
+// strategies in order and applies the first one which finds deductions.
+// A registry isn't safe for concurrent changes
+type Registry struct {
+	strategies []Strategy
+	disabled   map[string]bool
+}
+
+// NewRegistry returns a registry of the built-in strategies
+func NewRegistry() *Registry {
+	return &Registry{strategies: builtinStrategies(), disabled: map[string]bool{}}
+}
+
+// Register adds the strategy after the registered ones
+func (r *Registry) Register(s Strategy) error {
+	if r.index(s.Name()) >= 0 || s.Name() == nothing.Name() {
+		return fmt.Errorf("strategy %q is already registered", s.Name())
+	}
+	r.strategies = append(r.strategies, s)
+
+	return nil
+}
+
+// Unregister removes the strategy
+func (r *Registry) Unregister(name string) error {
+	i := r.index(name)
+	if i < 0 {
+		return fmt.Errorf("unknown strategy %q", name)
+	}
+	r.strategies = append(r.strategies[:i], r.strategies[i+1:]...)
+	delete(r.disabled, name)
+
+	return nil
+}
+
+// Reorder moves the named strategies to the front in the given order,
+// the rest keep their order after them
+func (r *Registry) Reorder(names ...string) error {
+	front := []Strategy{}
+	moved := map[string]bool{}
+	for _, name := range names {
+		i := r.index(name)
+		if i < 0 {
+			return fmt.Errorf("unknown strategy %q", name)
+		}
+		if moved[name] {
+			return fmt.Errorf("strategy %q is listed twice", name)
+		}
+		moved[name] = true
+		front = append(front, r.strategies[i])
+	}
+
+	for _, s := range r.strategies {
+		if !moved[s.Name()] {
+			front = append(front, s)
+		}
+	}
+	r.strategies = front
+
+	return nil
+}
+
+// Disable keeps the strategies registered, but boards don't use them
+func (r *Registry) Disable(names ...string) error {
+	return r.setDisabled(names, true)
+}
+
+// Enable enables disabled strategies
+func (r *Registry) Enable(names ...string) error {
+	return r.setDisabled(names, false)
+}
+
+func (r *Registry) setDisabled(names []string, disabled bool) error {
+	for _, name := range names {
+		if r.index(name) < 0 {
+			return fmt.Errorf("unknown strategy %q", name)
+		}
+	}
+	for _, name := range names {
+		if disabled {
+			r.disabled[name] = true
+		} else {
+			delete(r.disabled, name)
+		}
+	}
+
+	return nil
+}
+
+// Lookup returns the registered strategy of the name
+func (r *Registry) Lookup(name string) (Strategy, bool) {
+	i := r.index(name)
+	if i < 0 {
+		return nil, false
+	}
+
+	return r.strategies[i], true
+}
+
+// Names returns the names of all registered strategies in order
+func (r *Registry) Names() []string {
+	names := []string{}
+	for _, s := range r.strategies {
+		names = append(names, s.Name())
+	}
+
+	return names
+}
+
+// Strategies returns the enabled strategies in order
+func (r *Registry) Strategies() []Strategy {
+	enabled := []Strategy{}
+	for _, s := range r.strategies {
+		if !r.disabled[s.Name()] {
+			enabled = append(enabled, s)
+		}
+	}
+
+	return enabled
+}
+
+func (r *Registry) index(name string) int {
+	for i, s := range r.strategies {
+		if s.Name() == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// UseStrategies makes the board use the enabled strategies of the registry.
+// Later changes of the registry don't affect the board
+func (b *Board) UseStrategies(r *Registry) {
+	b.strategies = append([]Strategy{nothing}, r.Strategies()...)
+}
+
+// strategyIndex returns the index of the named strategy of the board,
+// 0 if the board doesn't use it
+func (b *Board) strategyIndex(name string) int {
+	for i, s := range b.strategies {
+		if i > 0 && s.Name() == name {
+			return i
+		}
+	}
+
+	return 0
+}
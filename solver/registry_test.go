@@ -0,0 +1,121 @@
+package solver
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fullHouse places the last digit of a unit with a single empty cell using only exported API
+func fullHouse(b *Board) []Step {
+	r := []Step{}
+	for y := 0; y < 9; y++ {
+		empty := []Position{}
+		var used [10]bool
+		for x := 0; x < 9; x++ {
+			p := Position{x, y}
+			if c := b.Candidates(p); len(c) == 1 {
+				used[c[0]] = true
+			} else {
+				empty = append(empty, p)
+			}
+		}
+		if len(empty) != 1 {
+			continue
+		}
+
+		s := Step{Cells: empty, Eliminations: []Candidate{}}
+		for _, d := range b.Candidates(empty[0]) {
+			if used[d] {
+				s.Eliminations = append(s.Eliminations, Candidate{empty[0], d})
+			}
+		}
+		if len(s.Eliminations) > 0 {
+			s.Description = fmt.Sprintf("Row %s has a single empty cell", empty[0].Name()[:1])
+			r = append(r, s)
+		}
+	}
+
+	return r
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	names := []string{"hidden singles", "naked singles", "naked pairs", "hidden pairs", "naked triples", "hidden triples", "naked quads", "hidden quads"}
+	if !reflect.DeepEqual(r.Names(), names) {
+		t.Errorf("registry names: %v, expected: %v", r.Names(), names)
+	}
+
+	if err := r.Register(NewStrategy("full house", 1.0, fullHouse)); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(NewStrategy("naked pairs", 1.0, fullHouse)); err == nil {
+		t.Errorf("duplicate strategy must be rejected")
+	}
+	if err := r.Reorder("full house", "hidden singles"); err != nil {
+		t.Fatal(err)
+	}
+	if n := r.Names(); n[0] != "full house" || n[1] != "hidden singles" || n[2] != "naked singles" {
+		t.Errorf("reordered names: %v", n)
+	}
+	if err := r.Reorder("x-wing"); err == nil {
+		t.Errorf("reordering an unknown strategy must fail")
+	}
+
+	if err := r.Disable("naked pairs", "hidden quads"); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Strategies()) != 7 || len(r.Names()) != 9 {
+		t.Errorf("enabled strategies: %d, registered: %d", len(r.Strategies()), len(r.Names()))
+	}
+	r.Enable("hidden quads")
+	if err := r.Unregister("hidden quads"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Lookup("hidden quads"); ok {
+		t.Errorf("unregistered strategy is still registered")
+	}
+}
+
+func TestUseStrategies(t *testing.T) {
+	r := NewRegistry()
+	r.Register(NewStrategy("full house", 1.0, fullHouse))
+	r.Reorder("full house")
+
+	b := NewBoard(nil, mediumPuzzle)
+	b.UseStrategies(r)
+	res := b.Solve()
+
+	used := map[string]bool{}
+	for _, s := range res.Steps {
+		used[s.Strategy] = true
+	}
+	if !res.Solved || !used["full house"] {
+		t.Errorf("solved: %v, strategies used: %v", res.Solved, used)
+	}
+
+	r = NewRegistry()
+	r.Disable("hidden pairs")
+	b = NewBoard(nil, mediumPuzzle)
+	b.UseStrategies(r)
+	res = b.Solve()
+	for _, s := range res.Steps {
+		if s.Strategy == "hidden pairs" {
+			t.Fatalf("disabled strategy was used: %s", s)
+		}
+	}
+	if res.HardestStrategy != "naked triples" {
+		t.Errorf("without hidden pairs the puzzle needs naked triples, hardest: %s", res.HardestStrategy)
+	}
+}
+
+func TestHardestStrategyOrder(t *testing.T) {
+	r := NewRegistry()
+	r.Reorder("hidden pairs")
+	b := NewBoard(nil, mediumPuzzle)
+	b.UseStrategies(r)
+	res := b.Solve()
+	if !res.Solved || res.HardestStrategy != "hidden pairs" || res.Rating.Max != 3.4 {
+		t.Errorf("hardest strategy must be the one of the highest difficulty, hardest: %s, rating: %+v", res.HardestStrategy, res.Rating)
+	}
+}
@@ -0,0 +1,69 @@
+package solver
+
+import (
+	// embed the result schema
+	_ "embed"
+)
+
+// ResultSchemaVersion is the version of the JSON schema of Result
+const ResultSchemaVersion = 1
+
+// ResultSchema is the JSON schema of Result
+//
+//go:embed schema/result.v1.json
+var ResultSchema []byte
+
+// Result is the machine readable outcome of solving a board
+type Result struct {
+	SchemaVersion int `json:"schema_version"`
+	// Puzzle is the board before solving
+	Puzzle string `json:"puzzle"`
+	// Grid is the board after solving, '.' is an unsolved cell
+	Grid string `json:"grid"`
+	// Candidates is the candidate grid of an unsolved board
+	Candidates string `json:"candidates,omitempty"`
+	Solved     bool   `json:"solved"`
+	// HardestStrategy is the most advanced strategy used
+	HardestStrategy string `json:"hardest_strategy"`
+	Rating          Rating `json:"rating"`
+	Steps           []Step `json:"steps"`
+	// StopReason is the reason solving stopped, set by SolveContext
+	StopReason StopReason `json:"stop_reason,omitempty"`
+}
+
+// Solve solves the board using all strategies
+func (b *Board) Solve() Result {
+	hardest, steps := b.solve(len(b.strategies)-1, Selection{})
+
+	return b.result(hardest, steps)
+}
+
+// SolveSteps solves the board like Solve and calls f with each step
+// as soon as it's applied. Solving stops at the first error returned by f
+func (b *Board) SolveSteps(f func(Step) error) (Result, error) {
+	var err error
+	b.onStep = func(s Step) bool {
+		err = f(s)
+		return err == nil
+	}
+	defer func() { b.onStep = nil }()
+
+	return b.Solve(), err
+}
+
+func (b *Board) result(hardest string, steps []Step) Result {
+	r := Result{
+		SchemaVersion:   ResultSchemaVersion,
+		Puzzle:          b.puzzle,
+		Grid:            b.codeStr(),
+		Solved:          b.isSolved(),
+		HardestStrategy: hardest,
+		Rating:          b.rating,
+		Steps:           steps,
+	}
+	if !r.Solved {
+		r.Candidates = b.CandidateString()
+	}
+
+	return r
+}
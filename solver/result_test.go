@@ -0,0 +1,73 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"testing"
+)
+
+func TestSolveResultJSON(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	b := NewBoard(log, mediumPuzzle)
+
+	data, err := json.Marshal(b.Solve())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := map[string]interface{}{}
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r["schema_version"] != float64(ResultSchemaVersion) || r["solved"] != true || r["hardest_strategy"] != "hidden pairs" {
+		t.Errorf("unexpected result: %s", data)
+	}
+	if _, ok := r["candidates"]; ok {
+		t.Errorf("solved result can't have candidates: %s", data)
+	}
+	if rating := r["rating"].(map[string]interface{}); rating["level"] != LevelMedium || rating["max"] != 3.4 {
+		t.Errorf("unexpected rating: %v", rating)
+	}
+	step := r["steps"].([]interface{})[0].(map[string]interface{})
+	for _, k := range []string{"strategy", "cells", "digits", "eliminations", "placements", "description"} {
+		if _, ok := step[k]; !ok {
+			t.Errorf("step has no %s: %v", k, step)
+		}
+	}
+}
+
+func TestResultSchema(t *testing.T) {
+	schema := map[string]interface{}{}
+	if err := json.Unmarshal(ResultSchema, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %s", err)
+	}
+
+	version := schema["properties"].(map[string]interface{})["schema_version"].(map[string]interface{})["const"]
+	if version != float64(ResultSchemaVersion) {
+		t.Errorf("schema version is: %v, expected: %d", version, ResultSchemaVersion)
+	}
+}
+
+func TestSolveStepsCallback(t *testing.T) {
+	streamed := []Step{}
+	r, err := NewBoard(nil, mediumPuzzle).SolveSteps(func(s Step) error {
+		streamed = append(streamed, s)
+		return nil
+	})
+	if err != nil || !r.Solved || !reflect.DeepEqual(streamed, r.Steps) {
+		t.Errorf("streamed %d steps, result has %d steps, error: %v", len(streamed), len(r.Steps), err)
+	}
+
+	stop := errors.New("stop")
+	n := 0
+	r, err = NewBoard(nil, mediumPuzzle).SolveSteps(func(s Step) error {
+		n++
+		return stop
+	})
+	if err != stop || n != 1 || r.Solved {
+		t.Errorf("expected solving to stop after the first step, got %d steps, error: %v", n, err)
+	}
+}
@@ -0,0 +1,46 @@
+package solver
+
+import "fmt"
+
+// Selection picks the strategies used for solving by name,
+// the zero value selects all strategies of the board
+type Selection struct {
+	// Include lists the only strategies to use, all are used if empty
+	Include []string
+	// Exclude lists the strategies not to use, it wins over Include
+	Exclude []string
+}
+
+// allows reports whether the strategy is selected
+func (s Selection) allows(name string) bool {
+	if len(s.Include) > 0 && !containsName(s.Include, name) {
+		return false
+	}
+
+	return !containsName(s.Exclude, name)
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckSelection returns an error if the selection names a strategy
+// the board doesn't use. Unknown names are ignored by solving, so a typo
+// would silently answer a different question
+func (b *Board) CheckSelection(s Selection) error {
+	for _, names := range [][]string{s.Include, s.Exclude} {
+		for _, name := range names {
+			if b.strategyIndex(name) == 0 {
+				return fmt.Errorf("unknown strategy %q", name)
+			}
+		}
+	}
+
+	return nil
+}
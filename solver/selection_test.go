@@ -0,0 +1,51 @@
+package solver
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSolveSelection(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+	res := b.SolveContext(context.Background(), SolveOptions{Strategies: Selection{Exclude: []string{"hidden pairs"}}})
+	for _, s := range res.Steps {
+		if s.Strategy == "hidden pairs" {
+			t.Fatalf("excluded strategy was used: %s", s)
+		}
+	}
+	if !res.Solved || res.HardestStrategy != "naked triples" {
+		t.Errorf("without hidden pairs the puzzle needs naked triples, solved: %v, hardest: %s", res.Solved, res.HardestStrategy)
+	}
+
+	b = NewBoard(nil, mediumPuzzle)
+	res = b.SolveContext(context.Background(), SolveOptions{Strategies: Selection{Include: []string{"naked singles", "hidden singles"}}})
+	if res.Solved || res.StopReason != StopStuck {
+		t.Errorf("the puzzle must not be solvable by singles, solved: %v, stop reason: %s", res.Solved, res.StopReason)
+	}
+	for _, s := range res.Steps {
+		if s.Strategy != "naked singles" && s.Strategy != "hidden singles" {
+			t.Fatalf("strategy not included was used: %s", s)
+		}
+	}
+}
+
+func TestSolveSelectionPropagate(t *testing.T) {
+	sel := Selection{Include: []string{"naked singles", "hidden singles"}, Exclude: []string{"naked singles"}}
+	b := NewBoard(nil, mediumPuzzle)
+	res := b.SolveContext(context.Background(), SolveOptions{Propagate: true, Strategies: sel})
+	for _, s := range res.Steps {
+		if s.Strategy != "hidden singles" {
+			t.Fatalf("exclude must win over include and propagation, step: %s", s)
+		}
+	}
+}
+
+func TestCheckSelection(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+	if err := b.CheckSelection(Selection{Include: []string{"naked pairs"}, Exclude: []string{"hidden quads"}}); err != nil {
+		t.Errorf("known strategies: %v", err)
+	}
+	if err := b.CheckSelection(Selection{Exclude: []string{"x-wing"}}); err == nil || err.Error() != `unknown strategy "x-wing"` {
+		t.Errorf("unknown strategy error is: %v", err)
+	}
+}
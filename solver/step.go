@@ -0,0 +1,121 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a cell position on the board
+type Position struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// Name returns the cell name, e.g. "A1"
+func (p Position) Name() string {
+	row, _ := utf8.DecodeRuneInString(rows[p.Y:])
+	col, _ := utf8.DecodeRuneInString(cols[p.X:])
+	return string(row) + string(col)
+}
+
+// Candidate is a digit in a cell of the board
+type Candidate struct {
+	Position
+	Digit int `json:"digit"`
+}
+
+func (c Candidate) String() string {
+	return fmt.Sprintf("%s(%d)", c.Name(), c.Digit)
+}
+
+// Link connects two candidates of a chain
+type Link struct {
+	From Candidate `json:"from"`
+	To   Candidate `json:"to"`
+	// Strong is true if at least one of the candidates is true
+	Strong bool `json:"strong"`
+}
+
+// Step is a single deduction made by a strategy
+type Step struct {
+	// Strategy is the name of the strategy which made the deduction
+	Strategy string `json:"strategy"`
+	// Unit is the unit the pattern was found in, e.g. "row A".
+	// It's empty for deductions about a single cell
+	Unit string `json:"unit,omitempty"`
+	// Cells and Digits form the pattern the deduction is based on
+	Cells  []Position `json:"cells"`
+	Digits []int      `json:"digits"`
+	// Links are the links of a chain based pattern
+	Links []Link `json:"links,omitempty"`
+	// Eliminations are candidates removed by the step
+	Eliminations []Candidate `json:"eliminations"`
+	// Placements are cells solved by the step
+	Placements []Candidate `json:"placements"`
+	// Description is a human readable explanation of the step
+	Description string `json:"description"`
+}
+
+func (s Step) String() string {
+	return s.Description
+}
+
+func positions(cells []*Cell) []Position {
+	r := []Position{}
+	for _, c := range cells {
+		r = append(r, Position{c.x, c.y})
+	}
+
+	return r
+}
+
+func cellNames(cells []*Cell) string {
+	names := []string{}
+	for _, c := range cells {
+		names = append(names, c.cellName())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Apply applies steps to the board and returns the ones which changed it.
+// Applied steps can be reverted with Undo
+func (b *Board) Apply(steps ...Step) []Step {
+	return b.apply(steps)
+}
+
+// apply applies steps to the board and returns the ones which changed it.
+// Eliminations and placements of the returned steps are limited to what
+// was actually changed
+func (b *Board) apply(steps []Step) []Step {
+	applied := []Step{}
+
+	for _, s := range steps {
+		eliminations := []Candidate{}
+		placements := []Candidate{}
+
+		for _, e := range s.Eliminations {
+			cell := b.cell(e.X, e.Y)
+			wasSolved := cell.isSolved()
+			if !b.eliminate(cell, digitMask(e.Digit)) {
+				continue
+			}
+			eliminations = append(eliminations, e)
+			if !wasSolved && cell.isSolved() {
+				placements = append(placements, Candidate{e.Position, cell.value()})
+			}
+		}
+
+		if len(eliminations) == 0 {
+			continue
+		}
+
+		s.Eliminations = eliminations
+		s.Placements = placements
+		applied = append(applied, s)
+		b.history = append(b.history, s)
+	}
+
+	return applied
+}
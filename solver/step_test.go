@@ -0,0 +1,64 @@
+package solver
+
+import (
+	"io/ioutil"
+	"log"
+	"reflect"
+	"testing"
+)
+
+func TestSolveSteps(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	b := NewBoard(log, mediumPuzzle)
+	puzzle := b.codeStr()
+
+	_, steps := b.solve(8, Selection{})
+	if len(steps) == 0 {
+		t.Fatalf("solve must return steps")
+	}
+
+	// replaying the placements must reproduce the solution
+	grid := []byte(puzzle)
+	for _, s := range steps {
+		if s.Strategy == "" || s.Description == "" || len(s.Eliminations) == 0 {
+			t.Errorf("step is not complete: %+v", s)
+		}
+		for _, p := range s.Placements {
+			grid[p.Y*9+p.X] = byte('0' + p.Digit)
+		}
+	}
+	if string(grid) != b.codeStr() {
+		t.Errorf("placements: %s, expected: %s", grid, b.codeStr())
+	}
+}
+
+func TestApplyStep(t *testing.T) {
+	log := log.New(ioutil.Discard, "", 0)
+	b := NewBoard(log, "000000001000000020000003000000040500006000300007810000010020004030000070950000000")
+	b.eliminate(b.cell(0, 0), allDigits&^maskOf(1, 2))
+
+	s := Step{
+		Strategy: "test",
+		Eliminations: []Candidate{
+			{Position{0, 0}, 1},
+			{Position{0, 0}, 3},
+		},
+	}
+	applied := b.apply([]Step{s, s})
+
+	ex := []Step{{
+		Strategy:     "test",
+		Eliminations: []Candidate{{Position{0, 0}, 1}},
+		Placements:   []Candidate{{Position{0, 0}, 2}},
+	}}
+	if !reflect.DeepEqual(applied, ex) {
+		t.Errorf("applied steps: %+v, expected: %+v", applied, ex)
+	}
+}
+
+func TestPositionName(t *testing.T) {
+	ex := "J9"
+	if n := (Position{8, 8}).Name(); n != ex {
+		t.Errorf("result: %s \n\texpected: %v", n, ex)
+	}
+}
@@ -1,110 +1,217 @@
 package solver
 
 import (
-	funk "github.com/thoas/go-funk"
-	"gonum.org/v1/gonum/stat/combin"
+	"fmt"
+	"math/bits"
 )
 
-type Strategy struct {
-	name string
-	f    strategyFunc
+// Strategy is a solving technique
+type Strategy interface {
+	// Name identifies the strategy in steps and registries, e.g. "naked pairs"
+	Name() string
+	// Difficulty is the weight of a single step of the strategy
+	// on the Sudoku Explainer scale
+	Difficulty() float64
+	// Apply finds deductions on the board. It must not change the board,
+	// the solver applies the returned steps
+	Apply(sudoku *Board) []Step
 }
 
-type strategyFunc func(sudoku *Board) bool
+// strategyFunc finds deductions on the board without applying them
+type strategyFunc func(sudoku *Board) []Step
 
-func SolveStripNakedSingles(sudoku *Board) bool {
-	r := []interface{}{}
-	c := combin.Cartesian(nil, [][]float64{MakeRange(9), MakeRange(9)})
-	rows, _ := c.Dims()
-	for i := 0; i < rows; i++ {
-		coords := c.RawRowView(i)
-		y, x := int(coords[0]), int(coords[1])
-		r = append(r, solveStripNakedSingle(sudoku, x, y))
+type funcStrategy struct {
+	name       string
+	difficulty float64
+	f          strategyFunc
+}
+
+// NewStrategy creates a strategy from a function finding its deductions
+func NewStrategy(name string, difficulty float64, f func(sudoku *Board) []Step) Strategy {
+	return &funcStrategy{name, difficulty, f}
+}
+
+func (s *funcStrategy) Name() string {
+	return s.name
+}
+
+func (s *funcStrategy) Difficulty() float64 {
+	return s.difficulty
+}
+
+func (s *funcStrategy) Apply(sudoku *Board) []Step {
+	return s.f(sudoku)
+}
+
+func SolveStripNakedSingles(sudoku *Board) []Step {
+	r := []Step{}
+	for _, c := range sudoku.fc {
+		if s, ok := solveStripNakedSingle(sudoku, c.x, c.y); ok {
+			r = append(r, s)
+		}
 	}
 
-	return Any(r)
+	return r
 }
 
-func NothingStrategy(sudoku *Board) bool {
-	return false
+func NothingStrategy(sudoku *Board) []Step {
+	return nil
 }
 
-func solveStripNakedSingle(sudoku *Board, x, y int) bool {
+func solveStripNakedSingle(sudoku *Board, x, y int) (Step, bool) {
 	cell := sudoku.cell(x, y)
 
 	if cell.isSolved() {
-		return false
+		return Step{}, false
 	}
 
-	seenValues := []float64{}
+	var seenValues mask
 	for _, v := range sudoku.seenFrom(x, y) {
-		seenValues = append(seenValues, float64(v.value()))
+		if v.isSolved() {
+			seenValues |= v.candidates
+		}
 	}
 
-	changed := cell.exclude(seenValues)
+	eliminated := cell.candidates & seenValues
+	if eliminated == 0 {
+		return Step{}, false
+	}
+	eliminations := []Candidate{}
+	for _, d := range eliminated.digits() {
+		eliminations = append(eliminations, Candidate{Position{x, y}, d})
+	}
 
-	if changed {
-		sudoku.log.Printf(" * Cell %s can only be %s\n", cell.cellName(), cell.stringValue())
+	remaining := (cell.candidates &^ seenValues).digits()
+	s := Step{
+		Cells:        []Position{{x, y}},
+		Digits:       remaining,
+		Eliminations: eliminations,
 	}
-	return changed
+	if len(remaining) == 1 {
+		s.Placements = []Candidate{{Position{x, y}, remaining[0]}}
+		s.Description = fmt.Sprintf("Cell %s can only be %d", cell.cellName(), remaining[0])
+	} else {
+		s.Description = fmt.Sprintf("Cell %s can only be %v", cell.cellName(), remaining)
+	}
+
+	return s, true
 }
 
-func SolveHiddenSingles(sudoku *Board) bool {
-	return solveHiddenNTuples(sudoku, 1)
+// SolveHiddenSingles finds digits with a single place in a unit. Places
+// seeing a solved digit don't count, so hidden singles are found before the
+// candidates are cleaned up by naked singles
+func SolveHiddenSingles(sudoku *Board) []Step {
+	// solved holds the digits of the solved cells of each unit
+	var solved [27]mask
+	for u, cells := range unitCells {
+		for _, i := range cells {
+			if c := sudoku.fc[i]; c.isSolved() {
+				solved[u] |= c.candidates
+			}
+		}
+	}
+
+	r := []Step{}
+	for _, t := range UnitType {
+		for i := 0; i < 9; i++ {
+			r = append(r, solveHiddenSinglesInUnit(sudoku, t, i, &solved)...)
+		}
+	}
+
+	return r
+}
+
+func solveHiddenSinglesInUnit(sudoku *Board, unitType string, i int, solved *[27]mask) []Step {
+	r := []Step{}
+	unitName := unitType + " " + sudoku.unitName(unitType, i)
+	u := unitOffset(unitType) + i
+
+	for d := 1; d <= 9; d++ {
+		if solved[u].has(d) {
+			continue
+		}
+
+		places := sudoku.placesOf(u, d)
+		for v := places; v != 0; v &= v - 1 {
+			p := bits.TrailingZeros16(uint16(v))
+			for _, up := range cellUnits[unitCells[u][p]] {
+				if solved[up.unit].has(d) {
+					places &^= 1 << uint(p)
+				}
+			}
+		}
+		if places.count() != 1 {
+			continue
+		}
+
+		cell := sudoku.fc[unitCells[u][bits.TrailingZeros16(uint16(places))]]
+		eliminations := []Candidate{}
+		for _, e := range (cell.candidates &^ digitMask(d)).digits() {
+			eliminations = append(eliminations, Candidate{Position{cell.x, cell.y}, e})
+		}
+		r = append(r, Step{
+			Unit:         unitName,
+			Cells:        []Position{{cell.x, cell.y}},
+			Digits:       []int{d},
+			Placements:   []Candidate{{Position{cell.x, cell.y}, d}},
+			Eliminations: eliminations,
+			Description:  fmt.Sprintf("In %s, only cell %s can be %d", unitName, cell.cellName(), d),
+		})
+	}
+
+	return r
 }
 
-func SolveNakedPairs(sudoku *Board) bool {
+func SolveNakedPairs(sudoku *Board) []Step {
 	return solveNakedNTuples(sudoku, 2)
 }
 
-func SolveNakedTriples(sudoku *Board) bool {
+func SolveNakedTriples(sudoku *Board) []Step {
 	return solveNakedNTuples(sudoku, 3)
 }
 
-func SolveNakedQuads(sudoku *Board) bool {
+func SolveNakedQuads(sudoku *Board) []Step {
 	return solveNakedNTuples(sudoku, 4)
 }
 
-func SolveHiddenPairs(sudoku *Board) bool {
+func SolveHiddenPairs(sudoku *Board) []Step {
 	return solveHiddenNTuples(sudoku, 2)
 }
 
-func SolveHiddenTriples(sudoku *Board) bool {
+func SolveHiddenTriples(sudoku *Board) []Step {
 	return solveHiddenNTuples(sudoku, 3)
 }
 
-func SolveHiddenQuads(sudoku *Board) bool {
+func SolveHiddenQuads(sudoku *Board) []Step {
 	return solveHiddenNTuples(sudoku, 4)
 }
 
-func solveNakedNTuples(sudoku *Board, n int) bool {
-	changed := false
+func solveNakedNTuples(sudoku *Board, n int) []Step {
+	r := []Step{}
 	for _, u := range UnitType {
 		for i := 0; i < 9; i++ {
-			if solveNakedNTuplesInUnit(sudoku, u, n, i) {
-				changed = true
-			}
+			r = append(r, solveNakedNTuplesInUnit(sudoku, u, n, i)...)
 		}
 	}
 
-	return changed
+	return r
 }
 
-func solveHiddenNTuples(sudoku *Board, n int) bool {
-	changed := false
+func solveHiddenNTuples(sudoku *Board, n int) []Step {
+	r := []Step{}
 	for _, u := range UnitType {
 		for i := 0; i < 9; i++ {
-			if solveHiddenNTuplesInUnit(sudoku, u, n, i) {
-				changed = true
-			}
+			r = append(r, solveHiddenNTuplesInUnit(sudoku, u, n, i)...)
 		}
 	}
 
-	return changed
+	return r
 }
 
-func solveHiddenNTuplesInUnit(sudoku *Board, unitType string, n, i int) bool {
-	changed := false
+func solveHiddenNTuplesInUnit(sudoku *Board, unitType string, n, i int) []Step {
+	r := []Step{}
+	unitName := unitType + " " + sudoku.unitName(unitType, i)
+	u := unitOffset(unitType) + i
 
 	filteredUnit := []*Cell{}
 	for _, c := range sudoku.unit(unitType, i) {
@@ -114,89 +221,88 @@ func solveHiddenNTuplesInUnit(sudoku *Board, unitType string, n, i int) bool {
 	}
 
 	for _, cells := range Combinations(filteredUnit, n) {
-		cellsCandidates := []float64{}
+		var set cellSet
+		var cellsCandidates mask
 		for _, c := range cells {
-			cellsCandidates = funk.UniqFloat64(append(cellsCandidates, c.candidates...))
+			set |= 1 << uint(cellUnits[c.y*9+c.x][u/9].pos)
+			cellsCandidates |= c.candidates
 		}
 
-		unitCandidates := []float64{}
-		for _, c := range Difference(sudoku.unit(unitType, i), cells) {
-			unitCandidates = funk.UniqFloat64(append(unitCandidates, c.candidates...))
+		// digits of the cells which can't go anywhere else in the unit
+		var nTupleUniques mask
+		for _, d := range cellsCandidates.digits() {
+			if sudoku.placesOf(u, d)&^set == 0 {
+				nTupleUniques |= digitMask(d)
+			}
 		}
 
-		nTupleUniques := DifferenceFloat64(cellsCandidates, unitCandidates)
-
-		if len(nTupleUniques) != n {
+		if nTupleUniques.count() != n {
 			continue
 		}
 
-		subsetChanged := false
+		eliminations := []Candidate{}
 		for _, cell := range cells {
-			subsetChanged = cell.includeOnly(nTupleUniques) || subsetChanged
-		}
-		changed = subsetChanged || changed
-
-		if subsetChanged {
-			if n == 1 {
-				cell := cells[0]
-				sudoku.log.Printf(" * In %s %s, only cell %s can be %d", unitType, sudoku.unitName(unitType, i), cell.cellName(), cell.value())
-			} else {
-				names := ""
-				for _, c := range cells {
-					names += c.cellName() + ", "
-				}
-				sudoku.log.Printf(" * In %s %s, only cells (%s) can be %v", unitType, sudoku.unitName(unitType, i), names, nTupleUniques)
+			for _, d := range (cell.candidates &^ nTupleUniques).digits() {
+				eliminations = append(eliminations, Candidate{Position{cell.x, cell.y}, d})
 			}
 		}
+		if len(eliminations) == 0 {
+			continue
+		}
+
+		r = append(r, Step{
+			Unit:         unitName,
+			Cells:        positions(cells),
+			Digits:       nTupleUniques.digits(),
+			Eliminations: eliminations,
+			Description:  fmt.Sprintf("In %s, only cells (%s) can be %v", unitName, cellNames(cells), nTupleUniques.digits()),
+		})
 	}
 
-	return changed
+	return r
 }
 
-func solveNakedNTuplesInUnit(sudoku *Board, unitType string, n, i int) bool {
-	changed := false
+func solveNakedNTuplesInUnit(sudoku *Board, unitType string, n, i int) []Step {
+	r := []Step{}
+	unitName := unitType + " " + sudoku.unitName(unitType, i)
 
 	filteredUnit := []*Cell{}
 	for _, c := range sudoku.unit(unitType, i) {
-		if len(c.candidates) >= 2 && len(c.candidates) <= n {
+		if k := c.candidates.count(); k >= 2 && k <= n {
 			filteredUnit = append(filteredUnit, c)
 		}
 	}
-	// spew.Dump(filteredUnit)
+
 	for _, cells := range Combinations(filteredUnit, n) {
-		candidates := []float64{}
+		var candidates mask
 		for _, c := range cells {
-			candidates = funk.UniqFloat64(append(candidates, c.candidates...))
+			candidates |= c.candidates
 		}
-		if len(candidates) != n {
+		if candidates.count() != n {
 			continue
 		}
-		// fmt.Printf("cells comb")
-		// spew.Dump(cells)
-		// spew.Dump(candidates)
-		unitChanged := false
-	OUTER:
+
+		eliminations := []Candidate{}
 		for _, cell := range sudoku.unit(unitType, i) {
-			for _, c := range cells {
-				if c == cell {
-					continue OUTER
-				}
+			if containsCell(cells, cell) {
+				continue
 			}
-			// spew.Dump(cell)
-			unitChanged = cell.exclude(candidates) || unitChanged
-			// spew.Dump(cell)
-			// fmt.Printf("unit: %t\n", unitChanged)
-		}
-		changed = unitChanged || changed
-		// fmt.Printf("changed: %t", changed)
-
-		if unitChanged {
-			names := ""
-			for _, c := range cells {
-				names += c.cellName() + ", "
+			for _, d := range (cell.candidates & candidates).digits() {
+				eliminations = append(eliminations, Candidate{Position{cell.x, cell.y}, d})
 			}
-			sudoku.log.Printf(" * In %s %s, cells (%s) can only be %v", unitType, sudoku.unitName(unitType, i), names, candidates)
 		}
+		if len(eliminations) == 0 {
+			continue
+		}
+
+		r = append(r, Step{
+			Unit:         unitName,
+			Cells:        positions(cells),
+			Digits:       candidates.digits(),
+			Eliminations: eliminations,
+			Description:  fmt.Sprintf("In %s, cells (%s) can only be %v", unitName, cellNames(cells), candidates.digits()),
+		})
 	}
-	return changed
+
+	return r
 }
@@ -0,0 +1,72 @@
+package solver
+
+// Static tables of the board geometry, cells are indexed row by row from 0 to 80
+
+// unitCells are the cells of the 27 units: 9 rows, 9 columns and 9 blocks
+var unitCells = func() (r [27][9]int) {
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			r[i][j] = i*9 + j
+			r[9+i][j] = j*9 + i
+			r[18+i][j] = (i/3*3+j/3)*9 + i%3*3 + j%3
+		}
+	}
+
+	return r
+}()
+
+// peerCells are the 20 cells sharing a unit with each cell
+var peerCells = func() (r [81][20]int) {
+	for i := range r {
+		n := 0
+		for j := 0; j < 81; j++ {
+			if j != i && (j/9 == i/9 || j%9 == i%9 || j/27*3+j%9/3 == i/27*3+i%9/3) {
+				r[i][n] = j
+				n++
+			}
+		}
+	}
+
+	return r
+}()
+
+// unitPosition is a unit of a cell and the position of the cell in it
+type unitPosition struct {
+	unit, pos int
+}
+
+// cellUnits are the row, column and block of each cell
+var cellUnits = func() (r [81][3]unitPosition) {
+	for u, cells := range unitCells {
+		for pos, i := range cells {
+			r[i][u/9] = unitPosition{u, pos}
+		}
+	}
+
+	return r
+}()
+
+// unitOffset returns the index of the first unit of the type in unitCells
+func unitOffset(t string) int {
+	switch t {
+	case typeRow:
+		return 0
+	case typeCol:
+		return 9
+	case typeBlock:
+		return 18
+	}
+
+	panic("unknown unit type " + t)
+}
+
+// containsCell reports whether c is one of cells
+func containsCell(cells []*Cell, c *Cell) bool {
+	for _, v := range cells {
+		if v == c {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package solver
+
+import "testing"
+
+func TestUnitTables(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+
+	for i, c := range b.fc {
+		units := 0
+		for _, u := range b.units {
+			if containsCell(u, c) {
+				units++
+			}
+		}
+		if units != 3 {
+			t.Errorf("cell %s is in %d units, expected 3", c.cellName(), units)
+		}
+
+		seen := map[*Cell]bool{}
+		for _, p := range b.peers[i] {
+			if p == c || seen[p] || (p.x != c.x && p.y != c.y && p.b != c.b) {
+				t.Errorf("%s isn't a peer of %s", p.cellName(), c.cellName())
+			}
+			seen[p] = true
+		}
+		if len(seen) != 20 {
+			t.Errorf("cell %s has %d peers, expected 20", c.cellName(), len(seen))
+		}
+	}
+
+	if block := b.block(5); block[0].cellName() != "D7" || block[8].cellName() != "F9" {
+		t.Errorf("block 6 is %s, expected D7 to F9", cellNames(block))
+	}
+	if col := b.unit(typeCol, 1); col[0].cellName() != "A2" || col[8].cellName() != "J2" {
+		t.Errorf("column 2 is %s, expected A2 to J2", cellNames(col))
+	}
+}
+
+func TestUnitTablesDontAllocate(t *testing.T) {
+	b := NewBoard(nil, mediumPuzzle)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 9; i++ {
+			b.row(i)
+			b.col(i)
+			b.block(i)
+			for _, u := range UnitType {
+				b.unit(u, i)
+				b.unitName(u, i)
+			}
+		}
+		for _, c := range b.fc {
+			b.seenFrom(c.x, c.y)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("unit lookups allocate %v times, expected 0", allocs)
+	}
+
+	b.Solve()
+	if allocs := testing.AllocsPerRun(100, func() { SolveStripNakedSingles(b) }); allocs != 0 {
+		t.Errorf("naked singles on a solved board allocate %v times, expected 0", allocs)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Olden/sudoku-solver/batch"
+)
+
+func statsMode(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	workers := fs.Int("workers", 0, "number of puzzles solved at once (default GOMAXPROCS)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s stats [flags] [file]\n\nSolves puzzles, one per line, read from the file or stdin and prints statistics.\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	in, err := openInput(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	s := batch.NewStats()
+	err = batch.Solve(context.Background(), in, batch.Options{Workers: *workers, Steps: true}, func(r batch.Result) error {
+		s.Add(r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		e := json.NewEncoder(os.Stdout)
+		e.SetIndent("", "  ")
+		return e.Encode(s.Report())
+	}
+
+	_, err = fmt.Print(s.Report())
+	return err
+}